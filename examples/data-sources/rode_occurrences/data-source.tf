@@ -0,0 +1,21 @@
+data "rode_occurrences" "example" {
+  resource_uri = "https://harbor.liatr.io/rode/demo-app@sha256:${var.image_digest}"
+  kinds        = ["ATTESTATION", "VULNERABILITY"]
+}
+
+resource "null_resource" "promote" {
+  lifecycle {
+    precondition {
+      condition     = data.rode_occurrences.example.attestation_count > 0
+      error_message = "The image has not been signed."
+    }
+
+    precondition {
+      condition = length([
+        for vulnerability in data.rode_occurrences.example.vulnerability :
+        vulnerability if contains(["HIGH", "CRITICAL"], vulnerability.effective_severity)
+      ]) == 0
+      error_message = "The image has high or critical vulnerabilities."
+    }
+  }
+}
@@ -0,0 +1,10 @@
+data "rode_policies" "security" {
+  name_prefix = "security-"
+}
+
+resource "rode_policy_assignment" "security" {
+  for_each = { for policy in data.rode_policies.security.policies : policy.name => policy }
+
+  policy_group      = "terraform-example"
+  policy_version_id = each.value.policy_version_id
+}
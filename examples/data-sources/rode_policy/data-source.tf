@@ -0,0 +1,12 @@
+data "rode_policy" "by_id" {
+  id = "5e8b8a3e-2f8a-4c1b-9a4e-0b5f5c8a1d2e"
+}
+
+data "rode_policy" "by_name" {
+  name = "example"
+}
+
+resource "rode_policy_assignment" "example" {
+  policy_group      = "terraform-example"
+  policy_version_id = data.rode_policy.by_name.policy_version_id
+}
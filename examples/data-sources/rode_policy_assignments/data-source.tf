@@ -0,0 +1,12 @@
+data "rode_policy_assignments" "production" {
+  policy_group = "production"
+}
+
+output "production_policy_version_ids" {
+  value = data.rode_policy_assignments.production.assignments[*].policy_version_id
+
+  precondition {
+    condition     = contains(data.rode_policy_assignments.production.assignments[*].policy_version_id, rode_policy.example.policy_version_id)
+    error_message = "The production policy group must enforce the current version of the example policy."
+  }
+}
@@ -0,0 +1,8 @@
+data "rode_policy_group" "example" {
+  name = "terraform-example"
+}
+
+resource "rode_policy_assignment" "example" {
+  policy_group      = data.rode_policy_group.example.name
+  policy_version_id = rode_policy.example.policy_version_id
+}
@@ -0,0 +1,11 @@
+data "rode_policy_groups" "production" {
+  filter              = "name.startsWith(\"prod-\")"
+  include_assignments = true
+}
+
+output "production_assignments" {
+  value = {
+    for group in data.rode_policy_groups.production.policy_groups :
+    group.name => group.assignments[*].policy_version_id
+  }
+}
@@ -0,0 +1,13 @@
+data "rode_policy_versions" "example" {
+  policy_id = rode_policy.example.id
+}
+
+locals {
+  versions = data.rode_policy_versions.example.versions
+}
+
+# keep the group on the previous version while the newest one bakes
+resource "rode_policy_assignment" "example" {
+  policy_group      = "production"
+  policy_version_id = local.versions[max(length(local.versions) - 2, 0)].id
+}
@@ -0,0 +1,11 @@
+data "rode_resource_evaluation" "example" {
+  resource_uri = "https://harbor.liatr.io/rode/demo-app@sha256:${var.image_digest}"
+  policy_group = "terraform-example"
+}
+
+output "failed_policies" {
+  value = [
+    for evaluation in data.rode_resource_evaluation.example.policy_evaluations :
+    evaluation.policy_version_id if !evaluation.pass
+  ]
+}
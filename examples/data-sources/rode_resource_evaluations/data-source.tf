@@ -0,0 +1,9 @@
+data "rode_resource_evaluations" "example" {
+  resource_uri = "https://harbor.liatr.io/rode/demo-app@sha256:${var.image_digest}"
+  filter       = "policyGroup == \"terraform-example\""
+  latest_only  = true
+}
+
+output "last_evaluated" {
+  value = data.rode_resource_evaluations.example.evaluations[0].created
+}
@@ -0,0 +1,10 @@
+data "rode_resource_versions" "example" {
+  resource_id = "https://harbor.liatr.io/rode/demo-app"
+  latest_only = true
+}
+
+resource "rode_resource_evaluation" "example" {
+  resource_uri      = data.rode_resource_versions.example.versions[0].version
+  policy_group      = "terraform-example"
+  fail_on_violation = true
+}
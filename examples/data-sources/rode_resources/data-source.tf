@@ -0,0 +1,4 @@
+data "rode_resources" "example" {
+  name_prefix = "https://harbor.liatr.io/rode/"
+  type        = "DOCKER"
+}
@@ -3,8 +3,32 @@ provider "rode" {
   host = "localhost:50051"
   // RODE_DISABLE_TRANSPORT_SECURITY
   disable_transport_security = true
+  // RODE_REQUEST_TIMEOUT
+  request_timeout = "1m"
+  // RODE_RETRY_MAX_ATTEMPTS
+  retry_max_attempts = 4
+  // RODE_RETRY_BASE_DELAY
+  retry_base_delay = "500ms"
+  // RODE_WAIT_FOR_READY_TIMEOUT
+  wait_for_ready_timeout = "0s"
+  // RODE_DISABLE_POLICY_VALIDATION
+  disable_policy_validation = false
 
-  // basic and oidc configuration is optional
+  // tls configuration is optional
+  // it can't be used alongside disable_transport_security, so remove that setting before uncommenting these
+
+  // RODE_CA_CERT_FILE
+  // ca_cert_file = "/etc/ssl/certs/internal-ca.pem"
+  // RODE_CLIENT_CERT
+  // client_cert = file("client.crt")
+  // RODE_CLIENT_KEY
+  // client_key = file("client.key")
+  // RODE_TLS_SERVER_NAME
+  // tls_server_name = "rode.internal"
+  // RODE_TLS_MIN_VERSION
+  // tls_min_version = "1.2"
+
+  // basic, oidc, and token configuration is optional
   // only one authentication method can be configured
 
   // RODE_OIDC_CLIENT_ID
@@ -21,4 +45,8 @@ provider "rode" {
   basic_username = "policy-administrator"
   // RODE_BASIC_PASSWORD
   basic_password = "password"
+  // RODE_TOKEN
+  token = var.ci_access_token
+  // runs a local credential helper that prints {"access_token": "...", "expires_at": "..."}
+  token_command = ["rode-login", "token", "--json"]
 }
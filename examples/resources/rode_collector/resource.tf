@@ -0,0 +1,17 @@
+resource "rode_collector" "example" {
+  collector_id = "terraform-example"
+
+  note {
+    kind              = "VULNERABILITY"
+    short_description = "Vulnerability scan"
+  }
+
+  note {
+    kind              = "BUILD"
+    short_description = "Build pipeline"
+  }
+}
+
+output "vulnerability_note_name" {
+  value = rode_collector.example.note_names["VULNERABILITY"]
+}
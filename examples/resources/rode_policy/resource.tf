@@ -18,5 +18,13 @@ violations[result] {
 		"message": "message",
 	}
 }
+EOF
+
+  test_content = <<EOF
+package tf_example
+
+test_pass {
+	pass with input as {}
+}
 EOF
 }
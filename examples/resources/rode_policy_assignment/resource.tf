@@ -30,3 +30,9 @@ resource "rode_policy_assignment" "example" {
   policy_group      = rode_policy_group.example.name
   policy_version_id = rode_policy.example.policy_version_id
 }
+
+resource "rode_policy_assignment" "previous_version" {
+  policy_group     = "production"
+  policy_id        = rode_policy.example.id
+  version_selector = "latest-1"
+}
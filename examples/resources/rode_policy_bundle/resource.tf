@@ -0,0 +1,15 @@
+resource "rode_policy_bundle" "example" {
+  source      = "${path.module}/policies"
+  description = "policy managed by Terraform"
+  message     = "Terraform"
+
+  timeouts {
+    create = "10m"
+    update = "10m"
+  }
+}
+
+resource "rode_policy_assignment" "example" {
+  policy_group      = "production"
+  policy_version_id = rode_policy_bundle.example.policy_version_ids["tf_example"]
+}
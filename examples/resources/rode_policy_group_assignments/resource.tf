@@ -0,0 +1,12 @@
+resource "rode_policy_group" "example" {
+  name        = "terraform-example"
+  description = "managed by Terraform"
+}
+
+resource "rode_policy_group_assignments" "example" {
+  policy_group = rode_policy_group.example.name
+  policy_version_ids = [
+    rode_policy.first.policy_version_id,
+    rode_policy.second.policy_version_id,
+  ]
+}
@@ -0,0 +1,8 @@
+resource "rode_resource_evaluation" "example" {
+  resource_uri      = "https://harbor.liatr.io/rode/demo-app@sha256:${var.image_digest}"
+  policy_group      = rode_policy_group.example.name
+  source_url        = var.ci_job_url
+  fail_on_violation = true
+
+  depends_on = [rode_policy_group_assignments.example]
+}
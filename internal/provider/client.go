@@ -15,39 +15,117 @@
 package provider
 
 import (
+	"context"
+	"crypto/tls"
+	"fmt"
 	"log"
 	"sync"
+	"time"
 
 	"github.com/rode/rode/common"
 	"github.com/rode/rode/proto/v1alpha1"
 	"google.golang.org/grpc"
 )
 
+const (
+	minInitBackoff = time.Second
+	maxInitBackoff = 30 * time.Second
+)
+
+// newRodeClient connects to Rode, it's replaced in tests to simulate Rode becoming ready
+var newRodeClient = common.NewRodeClient
+
 type rodeClient struct {
-	sync.Once
 	config *common.ClientConfig
 	v1alpha1.RodeClient
-	userAgent string
+	tlsConfig           *tls.Config
+	tokenCredentials    *tokenAuth
+	retry               *retryConfig
+	requestTimeout      time.Duration
+	waitForReadyTimeout time.Duration
+	userAgent           string
+
+	disablePolicyValidation bool
+
+	// initMu guards the initialization state below, so that a failed init can be retried after a backoff
+	initMu          sync.Mutex
+	initErr         error
+	initFailures    int
+	nextInitAttempt time.Time
 }
 
-var clientInitErr error
+// init connects to Rode the first time it's called. If connecting fails, the error is returned until the backoff
+// since the last attempt has passed, and then the next call tries again. With wait_for_ready_timeout, init keeps
+// trying until Rode is reachable, the timeout expires, or ctx is done.
+func (r *rodeClient) init(ctx context.Context) error {
+	r.initMu.Lock()
+	defer r.initMu.Unlock()
+
+	if r.RodeClient != nil {
+		return nil
+	}
 
-func (r *rodeClient) init() error {
-	r.Once.Do(func() {
+	if r.initErr != nil && time.Now().Before(r.nextInitAttempt) {
+		log.Printf("[DEBUG] Waiting until %s to retry Rode client init\n", r.nextInitAttempt.Format(time.RFC3339))
+		return r.initErr
+	}
+
+	deadline := time.Now().Add(r.waitForReadyTimeout)
+	for {
 		log.Println("[DEBUG] Rode client init")
-		rode, err := common.NewRodeClient(
-			r.config,
-			grpc.WithUserAgent(r.userAgent),
-		)
+		rode, err := r.dial()
+		if err == nil {
+			log.Println("[DEBUG] Rode client init successful")
+			r.RodeClient = rode
+			r.initErr = nil
+			r.initFailures = 0
+			return nil
+		}
 
-		if err != nil {
+		r.initErr = err
+		r.initFailures++
+		backoff := initBackoff(r.initFailures)
+		r.nextInitAttempt = time.Now().Add(backoff)
+
+		if r.nextInitAttempt.After(deadline) {
 			log.Printf("[ERROR] An error occurred initializing Rode client: %s\n", err)
+			return err
+		}
+
+		log.Printf("[WARN] Rode isn't ready yet, retrying in %s: %s\n", backoff, err)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("stopped waiting for Rode to be ready: %s: %s", ctx.Err(), err)
+		case <-time.After(backoff):
 		}
+	}
+}
+
+func (r *rodeClient) dial() (v1alpha1.RodeClient, error) {
+	dialOptions := []grpc.DialOption{grpc.WithUserAgent(r.userAgent)}
+	if r.tlsConfig != nil {
+		dialOptions = append(dialOptions, tlsDialOption(r.tlsConfig))
+	}
+	if r.tokenCredentials != nil {
+		dialOptions = append(dialOptions, grpc.WithPerRPCCredentials(r.tokenCredentials))
+	}
+	// the retry interceptor runs first, so that request_timeout applies to each attempt
+	var interceptors []grpc.UnaryClientInterceptor
+	if r.retry != nil && r.retry.maxAttempts > 1 {
+		interceptors = append(interceptors, r.retry.unaryInterceptor())
+	}
+	interceptors = append(interceptors, requestTimeoutInterceptor(r.requestTimeout))
+	dialOptions = append(dialOptions, grpc.WithChainUnaryInterceptor(interceptors...))
+
+	return newRodeClient(r.config, dialOptions...)
+}
 
-		r.RodeClient = rode
-		clientInitErr = err
-		log.Println("[DEBUG] Rode client init successful")
-	})
+// initBackoff doubles the delay before the next init attempt with each consecutive failure
+func initBackoff(failures int) time.Duration {
+	backoff := minInitBackoff << (failures - 1)
+	if backoff <= 0 || backoff > maxInitBackoff {
+		return maxInitBackoff
+	}
 
-	return clientInitErr
+	return backoff
 }
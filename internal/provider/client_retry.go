@@ -0,0 +1,111 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"log"
+	"math/rand"
+	"path"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxRetryDelay caps the exponential backoff between attempts
+const maxRetryDelay = 10 * time.Second
+
+// idempotentMethods are the RPCs that can be repeated without changing the result, in addition to the Get, List, and Validate RPCs.
+// CreatePolicy, UpdatePolicy, and EvaluateResource aren't idempotent: each call creates a new policy, policy version, or evaluation.
+var idempotentMethods = map[string]bool{
+	"EvaluatePolicy":    true,
+	"RegisterCollector": true,
+	"UpdatePolicyGroup": true,
+}
+
+type readAfterWriteKey struct{}
+
+// withReadAfterWrite marks a context used to read an object right after creating it.
+// Rode is backed by Elasticsearch, so the object may not be visible yet; NotFound errors are retried with this context.
+func withReadAfterWrite(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readAfterWriteKey{}, true)
+}
+
+type retryConfig struct {
+	maxAttempts int
+	baseDelay   time.Duration
+}
+
+// unaryInterceptor retries idempotent RPCs that fail with a transient error, with exponential backoff and jitter
+func (c *retryConfig) unaryInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		name := path.Base(method)
+		for attempt := 1; ; attempt++ {
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			if err == nil || attempt >= c.maxAttempts || !isRetryable(ctx, name, err) {
+				return err
+			}
+
+			delay := c.backoff(attempt)
+			log.Printf("[WARN] %s RPC failed with %s, retrying in %s (attempt %d of %d)\n", name, status.Code(err), delay, attempt, c.maxAttempts)
+
+			select {
+			case <-ctx.Done():
+				return err
+			case <-time.After(delay):
+			}
+		}
+	}
+}
+
+// backoff returns a random delay between half and all of the exponential backoff for the attempt
+func (c *retryConfig) backoff(attempt int) time.Duration {
+	delay := c.baseDelay << (attempt - 1)
+	if delay <= 0 || delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+func isRetryable(ctx context.Context, method string, err error) bool {
+	if ctx.Err() != nil || !isIdempotent(method) {
+		return false
+	}
+
+	switch status.Code(err) {
+	case codes.Unavailable, codes.Aborted, codes.DeadlineExceeded:
+		// DeadlineExceeded is only retried when the caller's context is still valid
+		return true
+	case codes.NotFound:
+		readAfterWrite, _ := ctx.Value(readAfterWriteKey{}).(bool)
+		return readAfterWrite
+	}
+
+	return false
+}
+
+func isIdempotent(method string) bool {
+	for _, prefix := range []string{"Get", "List", "Validate"} {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+
+	return idempotentMethods[method]
+}
@@ -0,0 +1,243 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestIsIdempotent(t *testing.T) {
+	tests := map[string]bool{
+		"GetPolicy":              true,
+		"ListPolicyAssignments":  true,
+		"ValidatePolicy":         true,
+		"EvaluatePolicy":         true,
+		"RegisterCollector":      true,
+		"UpdatePolicyGroup":      true,
+		"CreatePolicy":           false,
+		"UpdatePolicy":           false,
+		"DeletePolicy":           false,
+		"CreatePolicyAssignment": false,
+		"EvaluateResource":       false,
+	}
+
+	for method, expected := range tests {
+		if actual := isIdempotent(method); actual != expected {
+			t.Errorf("expected isIdempotent(%q) to be %t", method, expected)
+		}
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	expired, cancelExpired := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancelExpired()
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		method   string
+		code     codes.Code
+		expected bool
+	}{
+		{
+			name:     "unavailable read",
+			ctx:      context.Background(),
+			method:   "GetPolicy",
+			code:     codes.Unavailable,
+			expected: true,
+		},
+		{
+			name:     "aborted read",
+			ctx:      context.Background(),
+			method:   "ListPolicies",
+			code:     codes.Aborted,
+			expected: true,
+		},
+		{
+			name:   "unavailable create",
+			ctx:    context.Background(),
+			method: "CreatePolicy",
+			code:   codes.Unavailable,
+		},
+		{
+			name:   "invalid argument",
+			ctx:    context.Background(),
+			method: "GetPolicy",
+			code:   codes.InvalidArgument,
+		},
+		{
+			name:   "not found",
+			ctx:    context.Background(),
+			method: "GetPolicy",
+			code:   codes.NotFound,
+		},
+		{
+			name:     "not found after a write",
+			ctx:      withReadAfterWrite(context.Background()),
+			method:   "GetPolicy",
+			code:     codes.NotFound,
+			expected: true,
+		},
+		{
+			name:   "not found after a write for a non-idempotent RPC",
+			ctx:    withReadAfterWrite(context.Background()),
+			method: "CreatePolicyAssignment",
+			code:   codes.NotFound,
+		},
+		{
+			name:     "deadline exceeded for the attempt",
+			ctx:      context.Background(),
+			method:   "GetPolicy",
+			code:     codes.DeadlineExceeded,
+			expected: true,
+		},
+		{
+			name:   "deadline exceeded for the caller",
+			ctx:    expired,
+			method: "GetPolicy",
+			code:   codes.DeadlineExceeded,
+		},
+		{
+			name:   "canceled caller",
+			ctx:    canceled,
+			method: "GetPolicy",
+			code:   codes.Unavailable,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := isRetryable(tc.ctx, tc.method, status.Error(tc.code, "error")); actual != tc.expected {
+				t.Errorf("expected isRetryable to be %t", tc.expected)
+			}
+		})
+	}
+}
+
+func TestRetryConfig_backoff(t *testing.T) {
+	config := &retryConfig{baseDelay: 500 * time.Millisecond}
+	tests := map[int]time.Duration{
+		1:   500 * time.Millisecond,
+		2:   time.Second,
+		3:   2 * time.Second,
+		5:   8 * time.Second,
+		6:   maxRetryDelay,
+		100: maxRetryDelay,
+	}
+
+	for attempt, expected := range tests {
+		for i := 0; i < 100; i++ {
+			if delay := config.backoff(attempt); delay < expected/2 || delay > expected {
+				t.Fatalf("expected backoff for attempt %d to be between %s and %s, got %s", attempt, expected/2, expected, delay)
+			}
+		}
+	}
+}
+
+func TestRetryConfig_unaryInterceptor(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "unavailable")
+	tests := []struct {
+		name          string
+		method        string
+		errs          []error
+		expectedCalls int
+		expectedCode  codes.Code
+	}{
+		{
+			name:          "success",
+			method:        "/rode.v1alpha1.Rode/GetPolicy",
+			errs:          []error{nil},
+			expectedCalls: 1,
+			expectedCode:  codes.OK,
+		},
+		{
+			name:          "retried until success",
+			method:        "/rode.v1alpha1.Rode/GetPolicy",
+			errs:          []error{unavailable, unavailable, nil},
+			expectedCalls: 3,
+			expectedCode:  codes.OK,
+		},
+		{
+			name:          "gives up after max attempts",
+			method:        "/rode.v1alpha1.Rode/GetPolicy",
+			errs:          []error{unavailable, unavailable, unavailable, unavailable, nil},
+			expectedCalls: 3,
+			expectedCode:  codes.Unavailable,
+		},
+		{
+			name:          "non-idempotent RPC",
+			method:        "/rode.v1alpha1.Rode/CreatePolicy",
+			errs:          []error{unavailable, nil},
+			expectedCalls: 1,
+			expectedCode:  codes.Unavailable,
+		},
+		{
+			name:          "non-transient error",
+			method:        "/rode.v1alpha1.Rode/GetPolicy",
+			errs:          []error{status.Error(codes.PermissionDenied, "denied"), nil},
+			expectedCalls: 1,
+			expectedCode:  codes.PermissionDenied,
+		},
+	}
+
+	config := &retryConfig{maxAttempts: 3, baseDelay: time.Millisecond}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			calls := 0
+			invoker := func(context.Context, string, interface{}, interface{}, *grpc.ClientConn, ...grpc.CallOption) error {
+				err := tc.errs[calls]
+				calls++
+				return err
+			}
+
+			err := config.unaryInterceptor()(context.Background(), tc.method, nil, nil, nil, invoker)
+			if status.Code(err) != tc.expectedCode {
+				t.Errorf("expected %s, got %v", tc.expectedCode, err)
+			}
+
+			if calls != tc.expectedCalls {
+				t.Errorf("expected %d calls, got %d", tc.expectedCalls, calls)
+			}
+		})
+	}
+}
+
+func TestRetryConfig_unaryInterceptor_canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	config := &retryConfig{maxAttempts: 3, baseDelay: time.Minute}
+	calls := 0
+	invoker := func(context.Context, string, interface{}, interface{}, *grpc.ClientConn, ...grpc.CallOption) error {
+		calls++
+		cancel()
+		return status.Error(codes.Unavailable, "unavailable")
+	}
+
+	err := config.unaryInterceptor()(ctx, "/rode.v1alpha1.Rode/GetPolicy", nil, nil, nil, invoker)
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("expected the last error to be returned, got %v", err)
+	}
+
+	if calls != 1 {
+		t.Errorf("expected no retries once the context is canceled, got %d calls", calls)
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rode/rode/common"
+	"github.com/rode/rode/proto/v1alpha1"
+	"google.golang.org/grpc"
+)
+
+func TestInitBackoff(t *testing.T) {
+	tests := map[int]time.Duration{
+		1:   time.Second,
+		2:   2 * time.Second,
+		3:   4 * time.Second,
+		5:   16 * time.Second,
+		6:   maxInitBackoff,
+		100: maxInitBackoff,
+	}
+
+	for failures, expected := range tests {
+		if actual := initBackoff(failures); actual != expected {
+			t.Errorf("expected backoff after %d failures to be %s, got %s", failures, expected, actual)
+		}
+	}
+}
+
+func TestRodeClientInit_retryAfterFailure(t *testing.T) {
+	dials := testRodeClientDials(t, 1)
+	rode := &rodeClient{}
+
+	if err := rode.init(context.Background()); err == nil {
+		t.Fatal("expected the first init to fail")
+	}
+
+	if err := rode.init(context.Background()); err == nil || *dials != 1 {
+		t.Fatalf("expected the failure to be returned without dialing during the backoff, dialed %d times: %v", *dials, err)
+	}
+
+	rode.nextInitAttempt = time.Now()
+	if err := rode.init(context.Background()); err != nil {
+		t.Fatalf("expected init to succeed after the backoff: %s", err)
+	}
+
+	if err := rode.init(context.Background()); err != nil || *dials != 2 {
+		t.Fatalf("expected the client to be reused after init succeeds, dialed %d times: %v", *dials, err)
+	}
+
+	if rode.initFailures != 0 || rode.initErr != nil {
+		t.Errorf("expected the failures to be reset, got %d failures and error %v", rode.initFailures, rode.initErr)
+	}
+}
+
+func TestRodeClientInit_perClient(t *testing.T) {
+	testRodeClientDials(t, 1)
+	failed := &rodeClient{}
+	if err := failed.init(context.Background()); err == nil {
+		t.Fatal("expected the first client to fail")
+	}
+
+	if err := (&rodeClient{}).init(context.Background()); err != nil {
+		t.Errorf("expected another client to init independently: %s", err)
+	}
+}
+
+func TestRodeClientInit_waitForReady(t *testing.T) {
+	dials := testRodeClientDials(t, 1)
+	rode := &rodeClient{waitForReadyTimeout: time.Minute}
+
+	if err := rode.init(context.Background()); err != nil {
+		t.Fatalf("expected init to wait for Rode to be ready: %s", err)
+	}
+
+	if *dials != 2 {
+		t.Errorf("expected 2 dials, got %d", *dials)
+	}
+}
+
+func TestRodeClientInit_waitForReadyCanceled(t *testing.T) {
+	testRodeClientDials(t, 100)
+	rode := &rodeClient{waitForReadyTimeout: time.Minute}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := rode.init(ctx)
+	if err == nil || !strings.Contains(err.Error(), "stopped waiting for Rode to be ready") {
+		t.Fatalf("expected init to stop waiting, got %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > minInitBackoff {
+		t.Errorf("expected init to return when the context is done, took %s", elapsed)
+	}
+}
+
+// testRodeClientDials replaces newRodeClient with a function that fails the given number of times before connecting.
+// It returns a pointer to the number of dials.
+func testRodeClientDials(t *testing.T, failures int) *int {
+	dials := 0
+	original := newRodeClient
+	newRodeClient = func(*common.ClientConfig, ...grpc.DialOption) (v1alpha1.RodeClient, error) {
+		dials++
+		if dials <= failures {
+			return nil, errors.New("context deadline exceeded")
+		}
+
+		return v1alpha1.NewRodeClient(nil), nil
+	}
+	t.Cleanup(func() {
+		newRodeClient = original
+	})
+
+	return &dials
+}
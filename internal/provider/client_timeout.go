@@ -0,0 +1,53 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"path"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// requestTimeoutInterceptor applies request_timeout as the deadline of each RPC attempt,
+// and replaces deadline errors with a message that names the RPC and the timeout that was exceeded
+func requestTimeoutInterceptor(requestTimeout time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		callCtx := ctx
+		if requestTimeout > 0 {
+			var cancel context.CancelFunc
+			callCtx, cancel = context.WithTimeout(ctx, requestTimeout)
+			defer cancel()
+		}
+
+		err := invoker(callCtx, method, req, reply, cc, opts...)
+		if status.Code(err) != codes.DeadlineExceeded {
+			return err
+		}
+
+		name := path.Base(method)
+		if ctx.Err() == context.DeadlineExceeded {
+			return status.Errorf(codes.DeadlineExceeded, "%s RPC didn't complete before the operation timed out, the resource's timeouts may need to be increased", name)
+		}
+		if callCtx.Err() == context.DeadlineExceeded {
+			return status.Errorf(codes.DeadlineExceeded, "%s RPC timed out after %s, the provider's request_timeout may need to be increased", name, requestTimeout)
+		}
+
+		return err
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"google.golang.org/grpc"
+)
+
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// newTLSConfig builds the TLS configuration for the connection to Rode from the provider's TLS options.
+// It returns nil if none of the options are set, in which case the Rode client uses the system's defaults.
+func newTLSConfig(d *schema.ResourceData) (*tls.Config, error) {
+	caCertFile := d.Get("ca_cert_file").(string)
+	caCertPem := d.Get("ca_cert_pem").(string)
+	clientCert := d.Get("client_cert").(string)
+	clientKey := d.Get("client_key").(string)
+	serverName := d.Get("tls_server_name").(string)
+	minVersion := d.Get("tls_min_version").(string)
+
+	if caCertFile == "" && caCertPem == "" && clientCert == "" && clientKey == "" && serverName == "" && minVersion == "" {
+		return nil, nil
+	}
+
+	config := &tls.Config{
+		ServerName: serverName,
+	}
+
+	if minVersion != "" {
+		version, ok := tlsVersions[minVersion]
+		if !ok {
+			return nil, fmt.Errorf("unsupported TLS version %q", minVersion)
+		}
+		config.MinVersion = version
+	}
+
+	// the schema only checks these pairs in configuration, so values from environment variables are checked here
+	if caCertFile != "" && caCertPem != "" {
+		return nil, errors.New("only one of ca_cert_file and ca_cert_pem can be set")
+	}
+
+	if caCertFile != "" {
+		log.Printf("[DEBUG] Reading CA certificates from %s\n", caCertFile)
+		contents, err := os.ReadFile(caCertFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading ca_cert_file: %s", err)
+		}
+		caCertPem = string(contents)
+	}
+
+	if caCertPem != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(caCertPem)) {
+			return nil, errors.New("no valid PEM-encoded CA certificates were found")
+		}
+		config.RootCAs = pool
+	}
+
+	if (clientCert == "") != (clientKey == "") {
+		return nil, errors.New("client_cert and client_key must be set together")
+	}
+
+	if clientCert != "" {
+		certificate, err := tls.X509KeyPair([]byte(clientCert), []byte(clientKey))
+		if err != nil {
+			return nil, fmt.Errorf("error loading client certificate: %s", err)
+		}
+		config.Certificates = []tls.Certificate{certificate}
+	}
+
+	return config, nil
+}
+
+// tlsDialOption returns a dialer that establishes the TLS connection to Rode itself.
+// common.NewRodeClient always adds its own transport credentials with a default TLS configuration,
+// so the provider's TLS options are applied by dialing with transport security disabled at the gRPC level.
+func tlsDialOption(config *tls.Config) grpc.DialOption {
+	dialer := &tls.Dialer{
+		Config: config.Clone(),
+	}
+	dialer.Config.NextProtos = []string{"h2"}
+
+	return grpc.WithContextDialer(func(ctx context.Context, address string) (net.Conn, error) {
+		log.Printf("[DEBUG] Dialing %s with the provider's TLS configuration\n", address)
+		return dialer.DialContext(ctx, "tcp", address)
+	})
+}
@@ -0,0 +1,232 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestNewTLSConfig(t *testing.T) {
+	certPem, keyPem := testTLSCertificate(t)
+	_, otherKeyPem := testTLSCertificate(t)
+	caCertFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caCertFile, []byte(certPem), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		raw           map[string]interface{}
+		expectedError string
+		check         func(*tls.Config) bool
+	}{
+		{
+			name:  "no options",
+			raw:   map[string]interface{}{},
+			check: func(config *tls.Config) bool { return config == nil },
+		},
+		{
+			name:  "server name",
+			raw:   map[string]interface{}{"tls_server_name": "rode.internal"},
+			check: func(config *tls.Config) bool { return config.ServerName == "rode.internal" && config.RootCAs == nil },
+		},
+		{
+			name:          "unsupported min version",
+			raw:           map[string]interface{}{"tls_min_version": "1.4"},
+			expectedError: `unsupported TLS version "1.4"`,
+		},
+		{
+			name:  "ca_cert_pem",
+			raw:   map[string]interface{}{"ca_cert_pem": certPem},
+			check: func(config *tls.Config) bool { return config.RootCAs != nil },
+		},
+		{
+			name:  "ca_cert_file",
+			raw:   map[string]interface{}{"ca_cert_file": caCertFile},
+			check: func(config *tls.Config) bool { return config.RootCAs != nil },
+		},
+		{
+			name:          "ca_cert_file and ca_cert_pem",
+			raw:           map[string]interface{}{"ca_cert_file": caCertFile, "ca_cert_pem": certPem},
+			expectedError: "only one of ca_cert_file and ca_cert_pem can be set",
+		},
+		{
+			name:          "missing ca_cert_file",
+			raw:           map[string]interface{}{"ca_cert_file": filepath.Join(t.TempDir(), "missing.pem")},
+			expectedError: "error reading ca_cert_file",
+		},
+		{
+			name:          "invalid ca_cert_pem",
+			raw:           map[string]interface{}{"ca_cert_pem": "not a certificate"},
+			expectedError: "no valid PEM-encoded CA certificates were found",
+		},
+		{
+			name:          "client_cert without client_key",
+			raw:           map[string]interface{}{"client_cert": certPem},
+			expectedError: "client_cert and client_key must be set together",
+		},
+		{
+			name:          "client_key without client_cert",
+			raw:           map[string]interface{}{"client_key": keyPem},
+			expectedError: "client_cert and client_key must be set together",
+		},
+		{
+			name:          "mismatched client key",
+			raw:           map[string]interface{}{"client_cert": certPem, "client_key": otherKeyPem},
+			expectedError: "error loading client certificate",
+		},
+		{
+			name:  "client certificate",
+			raw:   map[string]interface{}{"client_cert": certPem, "client_key": keyPem},
+			check: func(config *tls.Config) bool { return len(config.Certificates) == 1 },
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			config, err := newTLSConfig(testProviderResourceData(t, tc.raw))
+			if tc.expectedError != "" {
+				if err == nil || !regexp.MustCompile(regexp.QuoteMeta(tc.expectedError)).MatchString(err.Error()) {
+					t.Fatalf("expected error %q, got %v", tc.expectedError, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !tc.check(config) {
+				t.Errorf("unexpected TLS configuration: %+v", config)
+			}
+		})
+	}
+}
+
+func TestNewTLSConfig_minVersion(t *testing.T) {
+	expected := map[string]uint16{
+		"1.0": tls.VersionTLS10,
+		"1.1": tls.VersionTLS11,
+		"1.2": tls.VersionTLS12,
+		"1.3": tls.VersionTLS13,
+	}
+
+	for version, expectedVersion := range expected {
+		t.Run(version, func(t *testing.T) {
+			config, err := newTLSConfig(testProviderResourceData(t, map[string]interface{}{"tls_min_version": version}))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if config.MinVersion != expectedVersion {
+				t.Errorf("expected min version %x, got %x", expectedVersion, config.MinVersion)
+			}
+		})
+	}
+}
+
+func TestProvider_tlsOptionConflicts(t *testing.T) {
+	certPem, keyPem := testTLSCertificate(t)
+	tests := map[string]struct {
+		raw           map[string]interface{}
+		expectedError string
+	}{
+		"ca_cert_file and ca_cert_pem": {
+			raw:           map[string]interface{}{"ca_cert_file": "ca.pem", "ca_cert_pem": certPem},
+			expectedError: `"ca_cert_file": conflicts with ca_cert_pem`,
+		},
+		"client_cert without client_key": {
+			raw:           map[string]interface{}{"client_cert": certPem},
+			expectedError: `"client_cert": all of ` + "`client_cert,client_key`" + ` must be specified`,
+		},
+		"client_key without client_cert": {
+			raw:           map[string]interface{}{"client_key": keyPem},
+			expectedError: `"client_key": all of ` + "`client_cert,client_key`" + ` must be specified`,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			diags := New("test")().Validate(terraform.NewResourceConfigRaw(tc.raw))
+			for _, d := range diags {
+				if d.Detail == tc.expectedError {
+					return
+				}
+			}
+
+			t.Errorf("expected error %q, got %+v", tc.expectedError, diags)
+		})
+	}
+}
+
+func TestProvider_tlsOptionsUnset(t *testing.T) {
+	if diags := New("test")().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{})); diags.HasError() {
+		t.Errorf("expected no errors, got %+v", diags)
+	}
+}
+
+func testProviderResourceData(t *testing.T, raw map[string]interface{}) *schema.ResourceData {
+	return schema.TestResourceDataRaw(t, New("test")().Schema, raw)
+}
+
+// testTLSCertificate generates a self-signed certificate and its PEM-encoded private key
+func testTLSCertificate(t *testing.T) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "rode.internal"},
+		DNSNames:              []string{"rode.internal"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	certificate, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	privateKey, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certPem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate})
+	keyPem := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: privateKey})
+
+	return string(certPem), string(keyPem)
+}
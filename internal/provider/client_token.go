@@ -0,0 +1,119 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// tokenExpiryDelta is how long before its expiration a token from token_command is refreshed
+const tokenExpiryDelta = 30 * time.Second
+
+// tokenCommandOutput is the JSON object that token_command is expected to print to stdout
+type tokenCommandOutput struct {
+	AccessToken string    `json:"access_token"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// tokenAuth sends a bearer token with each RPC, either a static token or one returned by token_command
+type tokenAuth struct {
+	token    string
+	command  []string
+	insecure bool
+
+	mu     sync.Mutex
+	cached *tokenCommandOutput
+}
+
+func newTokenAuth(token string, command []string, insecure bool) (*tokenAuth, error) {
+	if token != "" && len(command) != 0 {
+		return nil, errors.New("only one of token and token_command can be set")
+	}
+
+	return &tokenAuth{
+		token:    token,
+		command:  command,
+		insecure: insecure,
+	}, nil
+}
+
+func (t *tokenAuth) GetRequestMetadata(ctx context.Context, _ ...string) (map[string]string, error) {
+	token, err := t.accessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{
+		"authorization": "Bearer " + token,
+	}, nil
+}
+
+func (t *tokenAuth) RequireTransportSecurity() bool {
+	return !t.insecure
+}
+
+func (t *tokenAuth) accessToken(ctx context.Context) (string, error) {
+	if t.token != "" {
+		return t.token, nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.cached != nil && (t.cached.ExpiresAt.IsZero() || time.Now().Add(tokenExpiryDelta).Before(t.cached.ExpiresAt)) {
+		return t.cached.AccessToken, nil
+	}
+
+	output, err := runTokenCommand(ctx, t.command)
+	if err != nil {
+		return "", err
+	}
+	t.cached = output
+
+	return output.AccessToken, nil
+}
+
+func runTokenCommand(ctx context.Context, command []string) (*tokenCommandOutput, error) {
+	log.Printf("[DEBUG] Running token_command %s\n", command[0])
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("error running token_command: %s: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	output := &tokenCommandOutput{}
+	if err := json.Unmarshal(stdout.Bytes(), output); err != nil {
+		return nil, fmt.Errorf("error parsing token_command output as JSON: %s", err)
+	}
+
+	if output.AccessToken == "" {
+		return nil, errors.New("token_command output doesn't contain an access_token")
+	}
+	log.Printf("[DEBUG] token_command returned a token that expires at %s\n", output.ExpiresAt)
+
+	return output, nil
+}
@@ -0,0 +1,182 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTokenAuth_staticToken(t *testing.T) {
+	auth, err := newTokenAuth("static-token", nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	metadata, err := auth.GetRequestMetadata(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if metadata["authorization"] != "Bearer static-token" {
+		t.Errorf("expected static token to be sent, got %q", metadata["authorization"])
+	}
+
+	if !auth.RequireTransportSecurity() {
+		t.Error("expected token to require transport security")
+	}
+}
+
+func TestTokenAuth_insecure(t *testing.T) {
+	auth, err := newTokenAuth("static-token", nil, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if auth.RequireTransportSecurity() {
+		t.Error("expected token not to require transport security when it's disabled")
+	}
+}
+
+func TestTokenAuth_tokenAndCommand(t *testing.T) {
+	if _, err := newTokenAuth("static-token", []string{"rode-login"}, false); err == nil {
+		t.Error("expected an error when both token and token_command are set")
+	}
+}
+
+func TestTokenAuth_tokenCommand(t *testing.T) {
+	tests := []struct {
+		name          string
+		expiresIn     time.Duration
+		expectedCalls int
+	}{
+		{
+			name:          "cached until it expires",
+			expiresIn:     time.Hour,
+			expectedCalls: 1,
+		},
+		{
+			name:          "refreshed within tokenExpiryDelta of expiring",
+			expiresIn:     tokenExpiryDelta / 2,
+			expectedCalls: 2,
+		},
+		{
+			name:          "without an expiration",
+			expectedCalls: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			script := `printf '{"access_token": "token-%s"}' "$count"`
+			if tc.expiresIn != 0 {
+				expiresAt := time.Now().Add(tc.expiresIn).Format(time.RFC3339)
+				script = fmt.Sprintf(`printf '{"access_token": "token-%%s", "expires_at": "%s"}' "$count"`, expiresAt)
+			}
+			command, counter := testTokenCommand(t, script)
+
+			auth, err := newTokenAuth("", command, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var metadata map[string]string
+			for i := 0; i < 2; i++ {
+				metadata, err = auth.GetRequestMetadata(context.Background())
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if calls := counter(); calls != tc.expectedCalls {
+				t.Errorf("expected token_command to run %d times, ran %d times", tc.expectedCalls, calls)
+			}
+
+			expectedToken := fmt.Sprintf("Bearer token-%d", tc.expectedCalls)
+			if metadata["authorization"] != expectedToken {
+				t.Errorf("expected the latest token %q to be sent, got %q", expectedToken, metadata["authorization"])
+			}
+		})
+	}
+}
+
+func TestTokenAuth_tokenCommandErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		script        string
+		expectedError string
+	}{
+		{
+			name:          "missing access_token",
+			script:        `echo '{"expires_at": "2030-01-01T00:00:00Z"}'`,
+			expectedError: "token_command output doesn't contain an access_token",
+		},
+		{
+			name:          "invalid JSON",
+			script:        `echo 'not json'`,
+			expectedError: "error parsing token_command output as JSON",
+		},
+		{
+			name:          "non-zero exit",
+			script:        `echo 'not logged in' >&2; exit 3`,
+			expectedError: "error running token_command: exit status 3: not logged in",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			command, _ := testTokenCommand(t, tc.script)
+			auth, err := newTokenAuth("", command, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = auth.GetRequestMetadata(context.Background())
+			if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+				t.Errorf("expected error %q, got %v", tc.expectedError, err)
+			}
+		})
+	}
+}
+
+// testTokenCommand writes a shell script that runs body with $count set to the number of times it has been run.
+// It returns the command to run the script and a function that reads the count.
+func testTokenCommand(t *testing.T, body string) ([]string, func() int) {
+	dir := t.TempDir()
+	countFile := filepath.Join(dir, "count")
+	script := filepath.Join(dir, "token.sh")
+	contents := fmt.Sprintf("#!/bin/sh\ncount=$(($(cat %[1]q 2>/dev/null || echo 0) + 1))\necho $count > %[1]q\n%[2]s\n", countFile, body)
+	if err := os.WriteFile(script, []byte(contents), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	return []string{"/bin/sh", script}, func() int {
+		var count int
+		contents, err := os.ReadFile(countFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := fmt.Sscan(string(contents), &count); err != nil {
+			t.Fatal(err)
+		}
+
+		return count
+	}
+}
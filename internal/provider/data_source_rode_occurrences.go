@@ -0,0 +1,402 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/rode/rode/proto/v1alpha1"
+	"github.com/rode/rode/protodeps/grafeas/proto/v1beta1/common_go_proto"
+	grafeas_proto "github.com/rode/rode/protodeps/grafeas/proto/v1beta1/grafeas_go_proto"
+)
+
+// occurrenceBlocks maps the note kinds that are flattened by the rode_occurrences data source to their nested block
+var occurrenceBlocks = map[common_go_proto.NoteKind]string{
+	common_go_proto.NoteKind_BUILD:         "build",
+	common_go_proto.NoteKind_VULNERABILITY: "vulnerability",
+	common_go_proto.NoteKind_ATTESTATION:   "attestation",
+	common_go_proto.NoteKind_DEPLOYMENT:    "deployment",
+	common_go_proto.NoteKind_DISCOVERY:     "discovery",
+}
+
+func dataSourceOccurrences() *schema.Resource {
+	var kinds []string
+	for kind := range occurrenceBlocks {
+		kinds = append(kinds, kind.String())
+	}
+	sort.Strings(kinds)
+
+	dataSource := &schema.Resource{
+		Description: "Use this data source to list the occurrences of a resource version, e.g., to check for a build attestation or a vulnerability scan before promoting an artifact. Occurrences are flattened into a nested block per kind; occurrences of other kinds are ignored.",
+		ReadContext: dataSourceOccurrencesRead,
+		Schema: map[string]*schema.Schema{
+			"resource_uri": {
+				Description:  "The URI of the resource version. Uses the ListVersionedResourceOccurrences RPC, which also returns occurrences for other names of the version, unless `filter` is set.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				AtLeastOneOf: []string{"resource_uri", "filter"},
+			},
+			"filter": {
+				Description: "A CEL expression passed to the ListOccurrences RPC, e.g., `noteName == \"projects/rode/notes/harbor-vulnerability\"`. Combined with `resource_uri` if both are set.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"kinds": {
+				Description: fmt.Sprintf("Only return occurrences of these kinds. Must be one of %s.", strings.Join(kinds, ", ")),
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(kinds, false)),
+				},
+			},
+			"build": occurrencesSchema("Build occurrences", map[string]*schema.Schema{
+				"provenance_id": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"creator": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"logs_uri": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"builder_version": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"start_time": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"end_time": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"built_artifact_ids": {
+					Type:     schema.TypeList,
+					Computed: true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+			}),
+			"vulnerability": occurrencesSchema("Vulnerability occurrences", map[string]*schema.Schema{
+				"type": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"severity": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"effective_severity": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"cvss_score": {
+					Type:     schema.TypeFloat,
+					Computed: true,
+				},
+				"short_description": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"packages": {
+					Description: "The affected packages",
+					Type:        schema.TypeList,
+					Computed:    true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+			}),
+			"attestation": occurrencesSchema("Attestation occurrences", map[string]*schema.Schema{
+				"signature_type": {
+					Description: "Either `PGP` or `GENERIC`",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"content_type": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"key_ids": {
+					Description: "The ids of the keys used to sign the attestation",
+					Type:        schema.TypeList,
+					Computed:    true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+			}),
+			"deployment": occurrencesSchema("Deployment occurrences", map[string]*schema.Schema{
+				"user_email": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"deploy_time": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"undeploy_time": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"address": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"platform": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"resource_uris": {
+					Type:     schema.TypeList,
+					Computed: true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+			}),
+			"discovery": occurrencesSchema("Discovery occurrences", map[string]*schema.Schema{
+				"analysis_status": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"continuous_analysis": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"last_analysis_time": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			}),
+		},
+	}
+
+	for _, block := range occurrenceBlocks {
+		dataSource.Schema[block+"_count"] = &schema.Schema{
+			Description: fmt.Sprintf("The number of %s occurrences", block),
+			Type:        schema.TypeInt,
+			Computed:    true,
+		}
+	}
+
+	return dataSource
+}
+
+func dataSourceOccurrencesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rode := meta.(*rodeClient)
+	if err := rode.init(ctx); err != nil {
+		return diag.FromErr(err)
+	}
+
+	resourceUri := d.Get("resource_uri").(string)
+	filter := d.Get("filter").(string)
+	kinds := map[string]bool{}
+	for _, kind := range d.Get("kinds").(*schema.Set).List() {
+		kinds[kind.(string)] = true
+	}
+
+	occurrences, err := listOccurrences(ctx, rode, resourceUri, filter)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	flattened := map[string][]interface{}{}
+	for _, occurrence := range occurrences {
+		block, ok := occurrenceBlocks[occurrence.Kind]
+		if !ok || (len(kinds) != 0 && !kinds[occurrence.Kind.String()]) {
+			continue
+		}
+
+		flattened[block] = append(flattened[block], flattenOccurrence(occurrence))
+	}
+
+	for _, block := range occurrenceBlocks {
+		if err := d.Set(block, flattened[block]); err != nil {
+			return diag.FromErr(err)
+		}
+		d.Set(block+"_count", len(flattened[block]))
+	}
+
+	var sortedKinds []string
+	for kind := range kinds {
+		sortedKinds = append(sortedKinds, kind)
+	}
+	sort.Strings(sortedKinds)
+	d.SetId(dataSourceId(resourceUri, filter, strings.Join(sortedKinds, ",")))
+
+	return nil
+}
+
+// listOccurrences reads every page of ListVersionedResourceOccurrences for the resource version,
+// or of ListOccurrences when a filter is set
+func listOccurrences(ctx context.Context, rode *rodeClient, resourceUri, filter string) ([]*grafeas_proto.Occurrence, error) {
+	if filter == "" {
+		request := &v1alpha1.ListVersionedResourceOccurrencesRequest{
+			ResourceUri: resourceUri,
+			PageSize:    listPageSize,
+		}
+
+		var occurrences []*grafeas_proto.Occurrence
+		for {
+			log.Printf("[DEBUG] Calling ListVersionedResourceOccurrences RPC with: %v\n", request)
+			response, err := rode.ListVersionedResourceOccurrences(ctx, request)
+			if err != nil {
+				return nil, err
+			}
+
+			occurrences = append(occurrences, response.Occurrences...)
+			if response.NextPageToken == "" || len(response.Occurrences) == 0 {
+				return occurrences, nil
+			}
+			request.PageToken = response.NextPageToken
+		}
+	}
+
+	var resourceUriFilter string
+	if resourceUri != "" {
+		resourceUriFilter = fmt.Sprintf("resource.uri == %q", resourceUri)
+	}
+	request := &v1alpha1.ListOccurrencesRequest{
+		Filter:   combineFilters(filter, resourceUriFilter),
+		PageSize: listPageSize,
+	}
+
+	var occurrences []*grafeas_proto.Occurrence
+	for {
+		log.Printf("[DEBUG] Calling ListOccurrences RPC with: %v\n", request)
+		response, err := rode.ListOccurrences(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+
+		occurrences = append(occurrences, response.Occurrences...)
+		if response.NextPageToken == "" || len(response.Occurrences) == 0 {
+			return occurrences, nil
+		}
+		request.PageToken = response.NextPageToken
+	}
+}
+
+func flattenOccurrence(occurrence *grafeas_proto.Occurrence) map[string]interface{} {
+	flattened := map[string]interface{}{
+		"name":         occurrence.Name,
+		"note_name":    occurrence.NoteName,
+		"resource_uri": occurrence.GetResource().GetUri(),
+		"created":      formatProtoTimestamp(occurrence.CreateTime),
+	}
+
+	switch occurrence.Kind {
+	case common_go_proto.NoteKind_BUILD:
+		provenance := occurrence.GetBuild().GetProvenance()
+		var artifactIds []string
+		for _, artifact := range provenance.GetBuiltArtifacts() {
+			artifactIds = append(artifactIds, artifact.Id)
+		}
+
+		flattened["provenance_id"] = provenance.GetId()
+		flattened["creator"] = provenance.GetCreator()
+		flattened["logs_uri"] = provenance.GetLogsUri()
+		flattened["builder_version"] = provenance.GetBuilderVersion()
+		flattened["start_time"] = formatProtoTimestamp(provenance.GetStartTime())
+		flattened["end_time"] = formatProtoTimestamp(provenance.GetEndTime())
+		flattened["built_artifact_ids"] = artifactIds
+	case common_go_proto.NoteKind_VULNERABILITY:
+		vulnerability := occurrence.GetVulnerability()
+		var packages []string
+		for _, issue := range vulnerability.GetPackageIssue() {
+			packages = append(packages, issue.GetAffectedLocation().GetPackage())
+		}
+
+		flattened["type"] = vulnerability.GetType()
+		flattened["severity"] = vulnerability.GetSeverity().String()
+		flattened["effective_severity"] = vulnerability.GetEffectiveSeverity().String()
+		flattened["cvss_score"] = float64(vulnerability.GetCvssScore())
+		flattened["short_description"] = vulnerability.GetShortDescription()
+		flattened["packages"] = packages
+	case common_go_proto.NoteKind_ATTESTATION:
+		attestation := occurrence.GetAttestation().GetAttestation()
+		var keyIds []string
+		if pgp := attestation.GetPgpSignedAttestation(); pgp != nil {
+			flattened["signature_type"] = "PGP"
+			flattened["content_type"] = pgp.ContentType.String()
+			keyIds = append(keyIds, pgp.GetPgpKeyId())
+		} else if generic := attestation.GetGenericSignedAttestation(); generic != nil {
+			flattened["signature_type"] = "GENERIC"
+			flattened["content_type"] = generic.ContentType.String()
+			for _, signature := range generic.Signatures {
+				keyIds = append(keyIds, signature.PublicKeyId)
+			}
+		}
+		flattened["key_ids"] = keyIds
+	case common_go_proto.NoteKind_DEPLOYMENT:
+		deployment := occurrence.GetDeployment().GetDeployment()
+		flattened["user_email"] = deployment.GetUserEmail()
+		flattened["deploy_time"] = formatProtoTimestamp(deployment.GetDeployTime())
+		flattened["undeploy_time"] = formatProtoTimestamp(deployment.GetUndeployTime())
+		flattened["address"] = deployment.GetAddress()
+		flattened["platform"] = deployment.GetPlatform().String()
+		flattened["resource_uris"] = deployment.GetResourceUri()
+	case common_go_proto.NoteKind_DISCOVERY:
+		discovered := occurrence.GetDiscovered().GetDiscovered()
+		flattened["analysis_status"] = discovered.GetAnalysisStatus().String()
+		flattened["continuous_analysis"] = discovered.GetContinuousAnalysis().String()
+		flattened["last_analysis_time"] = formatProtoTimestamp(discovered.GetLastAnalysisTime())
+	}
+
+	return flattened
+}
+
+// occurrencesSchema describes a list of occurrences of a single kind, with the attributes shared by every occurrence
+func occurrencesSchema(description string, attributes map[string]*schema.Schema) *schema.Schema {
+	attributes["name"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+	attributes["note_name"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+	attributes["resource_uri"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+	attributes["created"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+
+	return &schema.Schema{
+		Description: description,
+		Type:        schema.TypeList,
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: attributes,
+		},
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccOccurrencesDataSource_basic(t *testing.T) {
+	collectorId := fmt.Sprintf("tf-acc-%s", strings.ToLower(fake.LetterN(10)))
+	resourceUri := testAccResourceUri()
+	config := testAccCollectorConfig(collectorId, "DISCOVERY")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProvidersFactory,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  testAccCreateOccurrence("rode_collector.test", resourceUri),
+			},
+			{
+				Config: config + fmt.Sprintf(`
+data "rode_occurrences" "all" {
+	resource_uri = "%[1]s"
+}
+
+data "rode_occurrences" "builds" {
+	resource_uri = "%[1]s"
+	kinds        = ["BUILD"]
+}
+
+data "rode_occurrences" "filtered" {
+	resource_uri = "%[1]s"
+	filter       = "noteName == \"${rode_collector.test.note_names.DISCOVERY}\""
+}
+`, resourceUri),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.rode_occurrences.all", "discovery_count", "1"),
+					resource.TestCheckResourceAttr("data.rode_occurrences.all", "build_count", "0"),
+					resource.TestCheckResourceAttr("data.rode_occurrences.all", "discovery.0.resource_uri", resourceUri),
+					resource.TestCheckResourceAttr("data.rode_occurrences.all", "discovery.0.analysis_status", "FINISHED_SUCCESS"),
+					resource.TestCheckResourceAttrPair("data.rode_occurrences.all", "discovery.0.note_name", "rode_collector.test", "note_names.DISCOVERY"),
+					resource.TestCheckResourceAttr("data.rode_occurrences.builds", "discovery_count", "0"),
+					resource.TestCheckResourceAttr("data.rode_occurrences.filtered", "discovery_count", "1"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,169 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/rode/rode/proto/v1alpha1"
+)
+
+func dataSourcePolicies() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to list the policies that match a filter.",
+		ReadContext: dataSourcePoliciesRead,
+		Schema: map[string]*schema.Schema{
+			"filter": {
+				Description: "A CEL expression used to filter policies, e.g., `name.startsWith(\"security-\")`",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"name_prefix": {
+				Description: "Only return policies whose name starts with this prefix. Combined with `filter` if both are set.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"include_deleted": {
+				Description: "Include policies that are marked as deleted, if Rode returns them. Rode currently excludes deleted policies from its search results.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"policies": {
+				Description: "The policies that matched the filter",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"current_version": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"policy_version_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"message": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"rego_content": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"created": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"updated": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"deleted": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePoliciesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rode := meta.(*rodeClient)
+	if err := rode.init(ctx); err != nil {
+		return diag.FromErr(err)
+	}
+
+	var namePrefixFilter string
+	if namePrefix := d.Get("name_prefix").(string); namePrefix != "" {
+		namePrefixFilter = fmt.Sprintf("name.startsWith(%q)", namePrefix)
+	}
+	filter := combineFilters(d.Get("filter").(string), namePrefixFilter)
+	includeDeleted := d.Get("include_deleted").(bool)
+
+	request := &v1alpha1.ListPoliciesRequest{
+		Filter:   filter,
+		PageSize: listPageSize,
+	}
+
+	var policies []interface{}
+	for {
+		log.Printf("[DEBUG] Calling ListPolicies RPC with: %v\n", request)
+		response, err := rode.ListPolicies(ctx, request)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		for _, policy := range response.Policies {
+			if policy.Deleted && !includeDeleted {
+				continue
+			}
+
+			policies = append(policies, flattenPolicy(policy))
+		}
+
+		if response.NextPageToken == "" || len(response.Policies) == 0 {
+			break
+		}
+		request.PageToken = response.NextPageToken
+	}
+
+	log.Printf("[DEBUG] Found %d policies\n", len(policies))
+	if err := d.Set("policies", policies); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(dataSourceId(filter, strconv.FormatBool(includeDeleted)))
+
+	return nil
+}
+
+func flattenPolicy(policy *v1alpha1.Policy) map[string]interface{} {
+	flattened := map[string]interface{}{
+		"id":              policy.Id,
+		"name":            policy.Name,
+		"description":     policy.Description,
+		"current_version": int(policy.CurrentVersion),
+		"created":         formatProtoTimestamp(policy.Created),
+		"updated":         formatProtoTimestamp(policy.Updated),
+		"deleted":         policy.Deleted,
+	}
+
+	if policy.Policy != nil {
+		flattened["policy_version_id"] = policy.Policy.Id
+		flattened["message"] = policy.Policy.Message
+		flattened["rego_content"] = policy.Policy.RegoContent
+	}
+
+	return flattened
+}
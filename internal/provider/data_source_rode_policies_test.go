@@ -0,0 +1,78 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPoliciesDataSource_basic(t *testing.T) {
+	dataSourceName := "data.rode_policies.test"
+	prefix := strings.ToLower(fmt.Sprintf("tf-acc-%s-", fake.LetterN(10)))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProvidersFactory,
+		CheckDestroy:      testAccPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPoliciesDataSourceConfig(prefix),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "policies.#", "2"),
+					resource.TestCheckTypeSetElemAttrPair(dataSourceName, "policies.*.id", "rode_policy.first", "id"),
+					resource.TestCheckTypeSetElemAttrPair(dataSourceName, "policies.*.id", "rode_policy.second", "id"),
+					resource.TestCheckTypeSetElemNestedAttrs(dataSourceName, "policies.*", map[string]string{
+						"name":            prefix + "first",
+						"current_version": "1",
+						"deleted":         "false",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccPoliciesDataSourceConfig(prefix string) string {
+	return fmt.Sprintf(`
+resource "rode_policy" "first" {
+	name         = "%[1]sfirst"
+	rego_content = <<EOF
+%[2]s
+EOF
+}
+
+resource "rode_policy" "second" {
+	name         = "%[1]ssecond"
+	rego_content = <<EOF
+%[2]s
+EOF
+}
+
+data "rode_policies" "test" {
+	name_prefix = "%[1]s"
+
+	depends_on = [
+		rode_policy.first,
+		rode_policy.second,
+	]
+}
+`, prefix, minimalPolicy)
+}
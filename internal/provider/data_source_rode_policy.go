@@ -0,0 +1,173 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/rode/rode/proto/v1alpha1"
+)
+
+var (
+	policyIdValidateDiagFunc = func(v interface{}, p cty.Path) diag.Diagnostics {
+		if _, err := uuid.ParseUUID(v.(string)); err != nil {
+			return diag.Errorf("invalid policy id: %s", err)
+		}
+
+		return nil
+	}
+)
+
+func dataSourcePolicy() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to look up an existing policy by id or by name.",
+		ReadContext: dataSourcePolicyRead,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Description:      "Unique identifier of the policy. Exactly one of `id` or `name` must be set.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ExactlyOneOf:     []string{"id", "name"},
+				ValidateDiagFunc: policyIdValidateDiagFunc,
+			},
+			"name": {
+				Description:  "Policy name. The lookup fails if no policy or more than one policy has this name.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+			},
+			"description": {
+				Description: "A brief summary of the policy",
+				Computed:    true,
+				Type:        schema.TypeString,
+			},
+			"current_version": {
+				Description: "Current version of the policy",
+				Computed:    true,
+				Type:        schema.TypeInt,
+			},
+			"policy_version_id": {
+				Description: "Policy version id",
+				Computed:    true,
+				Type:        schema.TypeString,
+			},
+			"message": {
+				Description: "A summary of changes since the last version",
+				Computed:    true,
+				Type:        schema.TypeString,
+			},
+			"rego_content": {
+				Description: "The Rego code",
+				Computed:    true,
+				Type:        schema.TypeString,
+			},
+			"created": {
+				Description: "Creation timestamp",
+				Computed:    true,
+				Type:        schema.TypeString,
+			},
+			"updated": {
+				Description: "Last updated timestamp",
+				Computed:    true,
+				Type:        schema.TypeString,
+			},
+			"deleted": {
+				Description: "Indicates that the policy has been deleted.",
+				Computed:    true,
+				Type:        schema.TypeBool,
+			},
+		},
+	}
+}
+
+func dataSourcePolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rode := meta.(*rodeClient)
+	if err := rode.init(ctx); err != nil {
+		return diag.FromErr(err)
+	}
+
+	var (
+		policy *v1alpha1.Policy
+		err    error
+	)
+	if id, ok := d.GetOk("id"); ok {
+		log.Println("[DEBUG] Calling GetPolicy RPC")
+		policy, err = rode.GetPolicy(ctx, &v1alpha1.GetPolicyRequest{Id: id.(string)})
+	} else {
+		policy, err = findPolicyByName(ctx, rode, d.Get("name").(string))
+	}
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(policy.Id)
+	d.Set("name", policy.Name)
+	d.Set("description", policy.Description)
+	d.Set("current_version", policy.CurrentVersion)
+	d.Set("policy_version_id", policy.Policy.Id)
+	d.Set("message", policy.Policy.Message)
+	d.Set("rego_content", policy.Policy.RegoContent)
+	d.Set("created", formatProtoTimestamp(policy.Created))
+	d.Set("updated", formatProtoTimestamp(policy.Updated))
+	d.Set("deleted", policy.Deleted)
+
+	return nil
+}
+
+func findPolicyByName(ctx context.Context, rode *rodeClient, name string) (*v1alpha1.Policy, error) {
+	request := &v1alpha1.ListPoliciesRequest{
+		Filter:   fmt.Sprintf("name == %q", name),
+		PageSize: listPageSize,
+	}
+
+	// Rode normalizes names to lowercase when searching, so filter for an exact match
+	var matches []*v1alpha1.Policy
+	for {
+		log.Printf("[DEBUG] Calling ListPolicies RPC with: %v\n", request)
+		response, err := rode.ListPolicies(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, policy := range response.Policies {
+			if policy.Name == name {
+				matches = append(matches, policy)
+			}
+		}
+
+		if response.NextPageToken == "" || len(response.Policies) == 0 {
+			break
+		}
+		request.PageToken = response.NextPageToken
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no policy found with name '%s'", name)
+	case 1:
+		return matches[0], nil
+	default:
+		return nil, fmt.Errorf("found %d policies with name '%s', use id to select one", len(matches), name)
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/rode/rode/proto/v1alpha1"
+)
+
+func dataSourcePolicyAssignments() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to list the policy assignments for a policy or a policy group.",
+		ReadContext: dataSourcePolicyAssignmentsRead,
+		Schema: map[string]*schema.Schema{
+			"policy_id": {
+				Description:      "Only return assignments for versions of this policy",
+				Type:             schema.TypeString,
+				Optional:         true,
+				AtLeastOneOf:     []string{"policy_id", "policy_group"},
+				ValidateDiagFunc: policyIdValidateDiagFunc,
+			},
+			"policy_group": {
+				Description:      "Only return assignments for this policy group",
+				Type:             schema.TypeString,
+				Optional:         true,
+				AtLeastOneOf:     []string{"policy_id", "policy_group"},
+				ValidateDiagFunc: policyGroupNameValidateDiagFunc,
+			},
+			"filter": {
+				Description: "A CEL expression used to further filter policy assignments",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"assignments": {
+				Description: "The matching policy assignments. Each `id` can be used to import a `rode_policy_assignment`.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        policyAssignmentElem(),
+			},
+		},
+	}
+}
+
+func dataSourcePolicyAssignmentsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rode := meta.(*rodeClient)
+	if err := rode.init(ctx); err != nil {
+		return diag.FromErr(err)
+	}
+
+	policyId := d.Get("policy_id").(string)
+	policyGroup := d.Get("policy_group").(string)
+	filter := d.Get("filter").(string)
+
+	assignments, err := listPolicyAssignments(ctx, rode, &v1alpha1.ListPolicyAssignmentsRequest{
+		PolicyId:    policyId,
+		PolicyGroup: policyGroup,
+		Filter:      filter,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var flattened []interface{}
+	for _, assignment := range assignments {
+		flattened = append(flattened, flattenPolicyAssignment(assignment))
+	}
+
+	log.Printf("[DEBUG] Found %d policy assignments\n", len(flattened))
+	if err := d.Set("assignments", flattened); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(dataSourceId(policyId, policyGroup, filter))
+
+	return nil
+}
+
+// listPolicyAssignments reads every page of the ListPolicyAssignments RPC
+func listPolicyAssignments(ctx context.Context, rode *rodeClient, request *v1alpha1.ListPolicyAssignmentsRequest) ([]*v1alpha1.PolicyAssignment, error) {
+	request.PageSize = listPageSize
+
+	var assignments []*v1alpha1.PolicyAssignment
+	for {
+		log.Printf("[DEBUG] Calling ListPolicyAssignments RPC with: %v\n", request)
+		response, err := rode.ListPolicyAssignments(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+
+		assignments = append(assignments, response.PolicyAssignments...)
+		if response.NextPageToken == "" || len(response.PolicyAssignments) == 0 {
+			return assignments, nil
+		}
+		request.PageToken = response.NextPageToken
+	}
+}
+
+// policyAssignmentElem describes the computed attributes of a policy assignment nested in a data source
+func policyAssignmentElem() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"policy_version_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"policy_group": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"created": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"updated": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func flattenPolicyAssignment(assignment *v1alpha1.PolicyAssignment) map[string]interface{} {
+	return map[string]interface{}{
+		"id":                assignment.Id,
+		"policy_version_id": assignment.PolicyVersionId,
+		"policy_group":      assignment.PolicyGroup,
+		"created":           formatProtoTimestamp(assignment.Created),
+		"updated":           formatProtoTimestamp(assignment.Updated),
+	}
+}
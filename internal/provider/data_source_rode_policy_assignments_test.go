@@ -0,0 +1,73 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/rode/rode/proto/v1alpha1"
+)
+
+func TestAccPolicyAssignmentsDataSource_basic(t *testing.T) {
+	byPolicyName := "data.rode_policy_assignments.by_policy"
+	byGroupName := "data.rode_policy_assignments.by_group"
+	policy := &v1alpha1.Policy{
+		Name: fmt.Sprintf("tf-acc-%s", fake.LetterN(10)),
+		Policy: &v1alpha1.PolicyEntity{
+			RegoContent: minimalPolicy,
+		},
+	}
+	policyGroup := &v1alpha1.PolicyGroup{
+		Name: fmt.Sprintf("tf-acc-%s", strings.ToLower(fake.LetterN(10))),
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProvidersFactory,
+		CheckDestroy:      testAccPolicyAssignmentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPolicyAssignmentFullConfig(policy, policyGroup) + `
+data "rode_policy_assignments" "by_policy" {
+	policy_id = rode_policy.test.id
+
+	depends_on = [rode_policy_assignment.test]
+}
+
+data "rode_policy_assignments" "by_group" {
+	policy_group = rode_policy_group.test.name
+
+	depends_on = [rode_policy_assignment.test]
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(byPolicyName, "assignments.#", "1"),
+					resource.TestCheckResourceAttrPair(byPolicyName, "assignments.0.id", "rode_policy_assignment.test", "id"),
+					resource.TestCheckResourceAttrPair(byPolicyName, "assignments.0.policy_version_id", "rode_policy.test", "policy_version_id"),
+					resource.TestCheckResourceAttr(byPolicyName, "assignments.0.policy_group", policyGroup.Name),
+					resource.TestCheckResourceAttrSet(byPolicyName, "assignments.0.created"),
+					resource.TestCheckResourceAttrSet(byPolicyName, "assignments.0.updated"),
+					resource.TestCheckResourceAttr(byGroupName, "assignments.#", "1"),
+					resource.TestCheckResourceAttrPair(byGroupName, "assignments.0.id", "rode_policy_assignment.test", "id"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,80 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/rode/rode/proto/v1alpha1"
+)
+
+func dataSourcePolicyGroup() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to look up an existing policy group by name.",
+		ReadContext: dataSourcePolicyGroupRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description:      "Unique identifier for the policy group",
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: policyGroupNameValidateDiagFunc,
+			},
+			"description": {
+				Description: "A brief summary of the intended use of the policy group",
+				Computed:    true,
+				Type:        schema.TypeString,
+			},
+			"created": {
+				Description: "Creation timestamp",
+				Computed:    true,
+				Type:        schema.TypeString,
+			},
+			"updated": {
+				Description: "Last updated timestamp",
+				Computed:    true,
+				Type:        schema.TypeString,
+			},
+			"deleted": {
+				Description: "Indicates that the policy group has been deleted.",
+				Computed:    true,
+				Type:        schema.TypeBool,
+			},
+		},
+	}
+}
+
+func dataSourcePolicyGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rode := meta.(*rodeClient)
+	if err := rode.init(ctx); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Println("[DEBUG] Calling GetPolicyGroup RPC")
+	policyGroup, err := rode.GetPolicyGroup(ctx, &v1alpha1.GetPolicyGroupRequest{Name: d.Get("name").(string)})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(policyGroup.Name)
+	d.Set("description", policyGroup.Description)
+	d.Set("created", formatProtoTimestamp(policyGroup.Created))
+	d.Set("updated", formatProtoTimestamp(policyGroup.Updated))
+	d.Set("deleted", policyGroup.Deleted)
+
+	return nil
+}
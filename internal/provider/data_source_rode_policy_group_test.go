@@ -0,0 +1,77 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/rode/rode/proto/v1alpha1"
+)
+
+func TestAccPolicyGroupDataSource_basic(t *testing.T) {
+	resourceName := "rode_policy_group.test"
+	dataSourceName := "data.rode_policy_group.test"
+	policyGroup := &v1alpha1.PolicyGroup{
+		Name:        fmt.Sprintf("tf-acc-%s", strings.ToLower(fake.LetterN(10))),
+		Description: fake.LetterN(10),
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProvidersFactory,
+		CheckDestroy:      testAccCheckPolicyGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPolicyGroupConfig(policyGroup) + `
+data "rode_policy_group" "test" {
+	name = rode_policy_group.test.name
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, "id", resourceName, "id"),
+					resource.TestCheckResourceAttr(dataSourceName, "description", policyGroup.Description),
+					resource.TestCheckResourceAttrPair(dataSourceName, "created", resourceName, "created"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "updated", resourceName, "updated"),
+					resource.TestCheckResourceAttr(dataSourceName, "deleted", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPolicyGroupDataSource_notFound(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProvidersFactory,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "rode_policy_group" "test" {
+	name = "tf-acc-%s"
+}
+`, strings.ToLower(fake.LetterN(10))),
+				ExpectError: regexp.MustCompile("policy group not found"),
+			},
+		},
+	})
+}
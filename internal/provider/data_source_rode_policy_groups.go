@@ -0,0 +1,142 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/rode/rode/proto/v1alpha1"
+)
+
+func dataSourcePolicyGroups() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to list the policy groups that match a filter.",
+		ReadContext: dataSourcePolicyGroupsRead,
+		Schema: map[string]*schema.Schema{
+			"filter": {
+				Description: "A CEL expression used to filter policy groups, e.g., `name.startsWith(\"prod-\")`",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"include_assignments": {
+				Description: "Populate `assignments` with the current policy assignments for each policy group.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"policy_groups": {
+				Description: "The policy groups that matched the filter",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"created": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"updated": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"deleted": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"assignments": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     policyAssignmentElem(),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePolicyGroupsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rode := meta.(*rodeClient)
+	if err := rode.init(ctx); err != nil {
+		return diag.FromErr(err)
+	}
+
+	filter := d.Get("filter").(string)
+	includeAssignments := d.Get("include_assignments").(bool)
+	request := &v1alpha1.ListPolicyGroupsRequest{
+		Filter:   filter,
+		PageSize: listPageSize,
+	}
+
+	var policyGroups []interface{}
+	for {
+		log.Printf("[DEBUG] Calling ListPolicyGroups RPC with: %v\n", request)
+		response, err := rode.ListPolicyGroups(ctx, request)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		for _, policyGroup := range response.PolicyGroups {
+			flattened := map[string]interface{}{
+				"name":        policyGroup.Name,
+				"description": policyGroup.Description,
+				"created":     formatProtoTimestamp(policyGroup.Created),
+				"updated":     formatProtoTimestamp(policyGroup.Updated),
+				"deleted":     policyGroup.Deleted,
+			}
+
+			if includeAssignments {
+				assignments, err := listPolicyAssignments(ctx, rode, &v1alpha1.ListPolicyAssignmentsRequest{
+					PolicyGroup: policyGroup.Name,
+				})
+				if err != nil {
+					return diag.FromErr(err)
+				}
+
+				var flattenedAssignments []interface{}
+				for _, assignment := range assignments {
+					flattenedAssignments = append(flattenedAssignments, flattenPolicyAssignment(assignment))
+				}
+				flattened["assignments"] = flattenedAssignments
+			}
+
+			policyGroups = append(policyGroups, flattened)
+		}
+
+		if response.NextPageToken == "" || len(response.PolicyGroups) == 0 {
+			break
+		}
+		request.PageToken = response.NextPageToken
+	}
+
+	log.Printf("[DEBUG] Found %d policy groups\n", len(policyGroups))
+	if err := d.Set("policy_groups", policyGroups); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(dataSourceId(filter, strconv.FormatBool(includeAssignments)))
+
+	return nil
+}
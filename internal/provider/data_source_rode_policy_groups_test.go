@@ -0,0 +1,67 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/rode/rode/proto/v1alpha1"
+)
+
+func TestAccPolicyGroupsDataSource_basic(t *testing.T) {
+	dataSourceName := "data.rode_policy_groups.test"
+	policy := &v1alpha1.Policy{
+		Name: fmt.Sprintf("tf-acc-%s", fake.LetterN(10)),
+		Policy: &v1alpha1.PolicyEntity{
+			RegoContent: minimalPolicy,
+		},
+	}
+	policyGroup := &v1alpha1.PolicyGroup{
+		Name:        fmt.Sprintf("tf-acc-%s", strings.ToLower(fake.LetterN(10))),
+		Description: fake.LetterN(10),
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProvidersFactory,
+		CheckDestroy:      testAccPolicyAssignmentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPolicyAssignmentFullConfig(policy, policyGroup) + fmt.Sprintf(`
+data "rode_policy_groups" "test" {
+	filter              = "name == \"%s\""
+	include_assignments = true
+
+	depends_on = [rode_policy_assignment.test]
+}
+`, policyGroup.Name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "policy_groups.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "policy_groups.0.name", policyGroup.Name),
+					resource.TestCheckResourceAttr(dataSourceName, "policy_groups.0.description", policyGroup.Description),
+					resource.TestCheckResourceAttr(dataSourceName, "policy_groups.0.deleted", "false"),
+					resource.TestCheckResourceAttr(dataSourceName, "policy_groups.0.assignments.#", "1"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "policy_groups.0.assignments.0.id", "rode_policy_assignment.test", "id"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "policy_groups.0.assignments.0.policy_version_id", "rode_policy.test", "policy_version_id"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,95 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/rode/rode/proto/v1alpha1"
+)
+
+func TestAccPolicyDataSource_basic(t *testing.T) {
+	resourceName := "rode_policy.test"
+	byIdName := "data.rode_policy.by_id"
+	byNameName := "data.rode_policy.by_name"
+	policy := &v1alpha1.Policy{
+		Name:        fmt.Sprintf("tf-acc-%s", fake.LetterN(10)),
+		Description: fake.LetterN(10),
+		Policy: &v1alpha1.PolicyEntity{
+			RegoContent: minimalPolicy,
+		},
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProvidersFactory,
+		CheckDestroy:      testAccPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPolicyDataSourceConfig(policy),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(byIdName, "id", resourceName, "id"),
+					resource.TestCheckResourceAttrPair(byIdName, "name", resourceName, "name"),
+					resource.TestCheckResourceAttrPair(byIdName, "description", resourceName, "description"),
+					resource.TestCheckResourceAttrPair(byIdName, "current_version", resourceName, "current_version"),
+					resource.TestCheckResourceAttrPair(byIdName, "policy_version_id", resourceName, "policy_version_id"),
+					resource.TestCheckResourceAttrPair(byIdName, "message", resourceName, "message"),
+					resource.TestCheckResourceAttrPair(byIdName, "rego_content", resourceName, "rego_content"),
+					resource.TestCheckResourceAttrPair(byIdName, "created", resourceName, "created"),
+					resource.TestCheckResourceAttrPair(byIdName, "updated", resourceName, "updated"),
+					resource.TestCheckResourceAttr(byIdName, "deleted", "false"),
+					resource.TestCheckResourceAttrPair(byNameName, "id", resourceName, "id"),
+					resource.TestCheckResourceAttrPair(byNameName, "policy_version_id", resourceName, "policy_version_id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPolicyDataSource_notFound(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProvidersFactory,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "rode_policy" "test" {
+	name = "tf-acc-%s"
+}
+`, fake.LetterN(10)),
+				ExpectError: regexp.MustCompile("no policy found with name"),
+			},
+		},
+	})
+}
+
+func testAccPolicyDataSourceConfig(policy *v1alpha1.Policy) string {
+	return testAccPolicyConfig(policy) + `
+data "rode_policy" "by_id" {
+	id = rode_policy.test.id
+}
+
+data "rode_policy" "by_name" {
+	name = rode_policy.test.name
+}
+`
+}
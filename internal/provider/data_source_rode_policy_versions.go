@@ -0,0 +1,134 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/rode/rode/proto/v1alpha1"
+)
+
+func dataSourcePolicyVersions() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to list every version of a policy.",
+		ReadContext: dataSourcePolicyVersionsRead,
+		Schema: map[string]*schema.Schema{
+			"policy_id": {
+				Description:      "Unique identifier of the policy",
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: policyIdValidateDiagFunc,
+			},
+			"versions": {
+				Description: "The versions of the policy, ordered from oldest to newest",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"version": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"message": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"rego_content": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"created": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePolicyVersionsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rode := meta.(*rodeClient)
+	if err := rode.init(ctx); err != nil {
+		return diag.FromErr(err)
+	}
+
+	policyId := d.Get("policy_id").(string)
+	policyVersions, err := listPolicyVersions(ctx, rode, policyId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var versions []interface{}
+	for _, policyVersion := range policyVersions {
+		components, err := parsePolicyVersionId(policyVersion.Id)
+		if err != nil {
+			return diag.Errorf("unexpected policy version id '%s': %s", policyVersion.Id, err)
+		}
+
+		versions = append(versions, map[string]interface{}{
+			"id":           policyVersion.Id,
+			"version":      components.version,
+			"message":      policyVersion.Message,
+			"rego_content": policyVersion.RegoContent,
+			"created":      formatProtoTimestamp(policyVersion.Created),
+		})
+	}
+
+	if err := d.Set("versions", versions); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(policyId)
+
+	return nil
+}
+
+// listPolicyVersions reads every page of the ListPolicyVersions RPC and returns the versions ordered from oldest to newest
+func listPolicyVersions(ctx context.Context, rode *rodeClient, policyId string) ([]*v1alpha1.PolicyEntity, error) {
+	request := &v1alpha1.ListPolicyVersionsRequest{
+		Id:       policyId,
+		PageSize: listPageSize,
+	}
+
+	var versions []*v1alpha1.PolicyEntity
+	for {
+		log.Printf("[DEBUG] Calling ListPolicyVersions RPC with: %v\n", request)
+		response, err := rode.ListPolicyVersions(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+
+		versions = append(versions, response.Versions...)
+		if response.NextPageToken == "" || len(response.Versions) == 0 {
+			break
+		}
+		request.PageToken = response.NextPageToken
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].Version < versions[j].Version
+	})
+
+	return versions, nil
+}
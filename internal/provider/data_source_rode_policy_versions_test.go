@@ -0,0 +1,69 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/rode/rode/proto/v1alpha1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestAccPolicyVersionsDataSource_basic(t *testing.T) {
+	dataSourceName := "data.rode_policy_versions.test"
+	policy := &v1alpha1.Policy{
+		Name: fmt.Sprintf("tf-acc-%s", fake.LetterN(10)),
+		Policy: &v1alpha1.PolicyEntity{
+			RegoContent: minimalPolicy,
+		},
+	}
+	updatedPolicy := proto.Clone(policy).(*v1alpha1.Policy)
+	updatedPolicy.Policy.Message = fake.LetterN(10)
+	updatedPolicy.Policy.RegoContent = updatedMinimalPolicy
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProvidersFactory,
+		CheckDestroy:      testAccPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPolicyConfig(policy),
+			},
+			{
+				Config: testAccPolicyConfig(updatedPolicy) + `
+data "rode_policy_versions" "test" {
+	policy_id = rode_policy.test.id
+
+	depends_on = [rode_policy.test]
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "versions.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "versions.0.version", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "versions.0.message", "Initial policy creation"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "versions.0.created"),
+					resource.TestCheckResourceAttr(dataSourceName, "versions.1.version", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "versions.1.message", updatedPolicy.Policy.Message),
+					resource.TestCheckResourceAttrPair(dataSourceName, "versions.1.id", "rode_policy.test", "policy_version_id"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "versions.1.rego_content", "rode_policy.test", "rego_content"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,98 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceResourceEvaluation() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to evaluate a resource against a policy group with the EvaluateResource RPC. A new evaluation is recorded in Rode every time the data source is read. Use the `rode_resource_evaluation` resource to only re-run the evaluation when its inputs change.",
+		ReadContext: dataSourceResourceEvaluationRead,
+		Schema: map[string]*schema.Schema{
+			"resource_uri": {
+				Description: "The URI of the resource version to evaluate",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"policy_group": {
+				Description:      "Name of the policy group to evaluate the resource against",
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: policyGroupNameValidateDiagFunc,
+			},
+			"source_name": {
+				Description: "Name of the system that requested the evaluation",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     defaultEvaluationSourceName,
+			},
+			"source_url": {
+				Description: "Link to the system that requested the evaluation, e.g., a CI job",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"fail_on_violation": {
+				Description: "Return an error if the resource fails the evaluation",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"policy_version_ids": {
+				Description: "The policy versions that were evaluated",
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"pass": resourceEvaluationPassSchema(),
+			"created": {
+				Description: "Evaluation timestamp",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"resource_version": {
+				Description: "The version of the resource that was evaluated",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"policy_evaluations": policyEvaluationsSchema(),
+		},
+	}
+}
+
+func dataSourceResourceEvaluationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rode := meta.(*rodeClient)
+	if err := rode.init(ctx); err != nil {
+		return diag.FromErr(err)
+	}
+
+	result, err := evaluateResource(ctx, rode, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(result.ResourceEvaluation.Id)
+
+	if err := setResourceEvaluationResult(d, result); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return failedResourceEvaluationDiagnostics(d)
+}
@@ -0,0 +1,84 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const failingPolicy = `package tf_acceptance
+
+pass {
+	false
+}
+
+violations[result] {
+	result = {
+		"pass": false,
+		"id": "invalid",
+		"name": "failing rule",
+		"description": "description",
+		"message": "the resource is never valid",
+	}
+}`
+
+func TestAccResourceEvaluationDataSource_basic(t *testing.T) {
+	dataSourceName := "data.rode_resource_evaluation.test"
+	name := fmt.Sprintf("tf-acc-%s", strings.ToLower(fake.LetterN(10)))
+	resourceUri := testAccResourceUri()
+	baseConfig := testAccResourceEvaluationBaseConfig(name, failingPolicy)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProvidersFactory,
+		Steps: []resource.TestStep{
+			{
+				Config: baseConfig,
+				Check:  testAccCreateOccurrence("rode_collector.test", resourceUri),
+			},
+			{
+				Config: baseConfig + testAccResourceEvaluationDataSourceConfig(resourceUri, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(dataSourceName, "id"),
+					resource.TestCheckResourceAttr(dataSourceName, "pass", "false"),
+					resource.TestCheckResourceAttr(dataSourceName, "policy_evaluations.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "policy_evaluations.0.pass", "false"),
+					resource.TestCheckResourceAttr(dataSourceName, "policy_evaluations.0.violations.0.message", "the resource is never valid"),
+				),
+			},
+			{
+				Config:      baseConfig + testAccResourceEvaluationDataSourceConfig(resourceUri, true),
+				ExpectError: regexp.MustCompile("Resource failed policy evaluation"),
+			},
+		},
+	})
+}
+
+func testAccResourceEvaluationDataSourceConfig(resourceUri string, failOnViolation bool) string {
+	return fmt.Sprintf(`
+data "rode_resource_evaluation" "test" {
+	resource_uri      = "%s"
+	policy_group      = rode_policy_assignment.test.policy_group
+	fail_on_violation = %t
+}
+`, resourceUri, failOnViolation)
+}
@@ -0,0 +1,188 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/rode/rode/proto/v1alpha1"
+)
+
+func dataSourceResourceEvaluations() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to list the evaluation history of a resource version.",
+		ReadContext: dataSourceResourceEvaluationsRead,
+		Schema: map[string]*schema.Schema{
+			"resource_uri": {
+				Description: "The URI of the resource version",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"filter": {
+				Description:   "A CEL expression used to filter evaluations, e.g., `policyGroup == \"production\"`",
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"evaluation_id"},
+			},
+			"latest_only": {
+				Description:   "Only return the newest evaluation",
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ConflictsWith: []string{"evaluation_id"},
+			},
+			"evaluation_id": {
+				Description: "Only return the evaluation with this id. The evaluation must belong to `resource_uri`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"evaluations": {
+				Description: "The evaluations of the resource version, ordered from newest to oldest",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"pass": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"policy_group": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"source_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"source_url": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"created": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"resource_version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"policy_evaluations": policyEvaluationsSchema(),
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceResourceEvaluationsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rode := meta.(*rodeClient)
+	if err := rode.init(ctx); err != nil {
+		return diag.FromErr(err)
+	}
+
+	resourceUri := d.Get("resource_uri").(string)
+	filter := d.Get("filter").(string)
+	latestOnly := d.Get("latest_only").(bool)
+	evaluationId := d.Get("evaluation_id").(string)
+
+	var (
+		results []*v1alpha1.ResourceEvaluationResult
+		err     error
+	)
+	if evaluationId != "" {
+		results, err = getResourceEvaluation(ctx, rode, resourceUri, evaluationId)
+	} else {
+		results, err = listResourceEvaluations(ctx, rode, &v1alpha1.ListResourceEvaluationsRequest{
+			ResourceUri: resourceUri,
+			Filter:      filter,
+		}, latestOnly)
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var evaluations []interface{}
+	for _, result := range results {
+		evaluations = append(evaluations, flattenResourceEvaluationResult(result))
+	}
+
+	if err := d.Set("evaluations", evaluations); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(dataSourceId(resourceUri, filter, strconv.FormatBool(latestOnly), evaluationId))
+
+	return nil
+}
+
+func getResourceEvaluation(ctx context.Context, rode *rodeClient, resourceUri, evaluationId string) ([]*v1alpha1.ResourceEvaluationResult, error) {
+	log.Printf("[DEBUG] Calling GetResourceEvaluation RPC for %s\n", evaluationId)
+	result, err := rode.GetResourceEvaluation(ctx, &v1alpha1.GetResourceEvaluationRequest{Id: evaluationId})
+	if err != nil {
+		return nil, err
+	}
+
+	if result.ResourceEvaluation.ResourceVersion.GetVersion() != resourceUri {
+		return nil, fmt.Errorf("resource evaluation %s is for %s, not %s", evaluationId, result.ResourceEvaluation.ResourceVersion.GetVersion(), resourceUri)
+	}
+
+	return []*v1alpha1.ResourceEvaluationResult{result}, nil
+}
+
+// listResourceEvaluations reads every page of the ListResourceEvaluations RPC, or only the newest evaluation when latestOnly is set.
+// Rode returns evaluations ordered from newest to oldest.
+func listResourceEvaluations(ctx context.Context, rode *rodeClient, request *v1alpha1.ListResourceEvaluationsRequest, latestOnly bool) ([]*v1alpha1.ResourceEvaluationResult, error) {
+	request.PageSize = listPageSize
+	if latestOnly {
+		request.PageSize = 1
+	}
+
+	var results []*v1alpha1.ResourceEvaluationResult
+	for {
+		log.Printf("[DEBUG] Calling ListResourceEvaluations RPC with: %v\n", request)
+		response, err := rode.ListResourceEvaluations(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, response.ResourceEvaluations...)
+		if latestOnly || response.NextPageToken == "" || len(response.ResourceEvaluations) == 0 {
+			return results, nil
+		}
+		request.PageToken = response.NextPageToken
+	}
+}
+
+func flattenResourceEvaluationResult(result *v1alpha1.ResourceEvaluationResult) map[string]interface{} {
+	evaluation := result.ResourceEvaluation
+
+	return map[string]interface{}{
+		"id":                 evaluation.Id,
+		"pass":               evaluation.Pass,
+		"policy_group":       evaluation.PolicyGroup,
+		"source_name":        evaluation.Source.GetName(),
+		"source_url":         evaluation.Source.GetUrl(),
+		"created":            formatProtoTimestamp(evaluation.Created),
+		"resource_version":   evaluation.ResourceVersion.GetVersion(),
+		"policy_evaluations": flattenPolicyEvaluations(result.PolicyEvaluations),
+	}
+}
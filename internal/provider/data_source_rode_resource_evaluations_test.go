@@ -0,0 +1,74 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceEvaluationsDataSource_basic(t *testing.T) {
+	resourceName := "rode_resource_evaluation.test"
+	name := fmt.Sprintf("tf-acc-%s", strings.ToLower(fake.LetterN(10)))
+	resourceUri := testAccResourceUri()
+	baseConfig := testAccResourceEvaluationBaseConfig(name, minimalPolicy)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProvidersFactory,
+		Steps: []resource.TestStep{
+			{
+				Config: baseConfig,
+				Check:  testAccCreateOccurrence("rode_collector.test", resourceUri),
+			},
+			{
+				Config: baseConfig + testAccResourceEvaluationConfig(resourceUri),
+			},
+			{
+				Config: baseConfig + testAccResourceEvaluationConfig(resourceUri) + `
+data "rode_resource_evaluations" "all" {
+	resource_uri = rode_resource_evaluation.test.resource_uri
+}
+
+data "rode_resource_evaluations" "latest" {
+	resource_uri = rode_resource_evaluation.test.resource_uri
+	latest_only  = true
+}
+
+data "rode_resource_evaluations" "by_id" {
+	resource_uri  = rode_resource_evaluation.test.resource_uri
+	evaluation_id = rode_resource_evaluation.test.id
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.rode_resource_evaluations.all", "evaluations.#", "1"),
+					resource.TestCheckResourceAttr("data.rode_resource_evaluations.latest", "evaluations.#", "1"),
+					resource.TestCheckResourceAttrPair("data.rode_resource_evaluations.latest", "evaluations.0.id", resourceName, "id"),
+					resource.TestCheckResourceAttrPair("data.rode_resource_evaluations.latest", "evaluations.0.created", resourceName, "created"),
+					resource.TestCheckResourceAttr("data.rode_resource_evaluations.latest", "evaluations.0.pass", "true"),
+					resource.TestCheckResourceAttr("data.rode_resource_evaluations.latest", "evaluations.0.policy_group", name),
+					resource.TestCheckResourceAttr("data.rode_resource_evaluations.latest", "evaluations.0.source_name", defaultEvaluationSourceName),
+					resource.TestCheckResourceAttr("data.rode_resource_evaluations.latest", "evaluations.0.policy_evaluations.#", "1"),
+					resource.TestCheckResourceAttrPair("data.rode_resource_evaluations.by_id", "evaluations.0.id", resourceName, "id"),
+				),
+			},
+		},
+	})
+}
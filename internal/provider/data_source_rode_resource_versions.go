@@ -0,0 +1,125 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/rode/rode/proto/v1alpha1"
+)
+
+func dataSourceResourceVersions() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to list the versions of a resource, e.g., to find the latest version of a Docker image.",
+		ReadContext: dataSourceResourceVersionsRead,
+		Schema: map[string]*schema.Schema{
+			"resource_id": {
+				Description: "Unique identifier of the resource, e.g., `https://harbor.liatr.io/rode/demo-app` for a Docker image",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"filter": {
+				Description: "A CEL expression used to filter versions, e.g., `names.contains(\"latest\")`",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"latest_only": {
+				Description: "Only return the newest version",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"versions": {
+				Description: "The versions of the resource, ordered from newest to oldest",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"version": {
+							Description: "The URI of the resource version",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"names": {
+							Description: "Other names for the version, such as Docker tags",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"created": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceResourceVersionsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rode := meta.(*rodeClient)
+	if err := rode.init(ctx); err != nil {
+		return diag.FromErr(err)
+	}
+
+	resourceId := d.Get("resource_id").(string)
+	filter := d.Get("filter").(string)
+	latestOnly := d.Get("latest_only").(bool)
+
+	request := &v1alpha1.ListResourceVersionsRequest{
+		Id:       resourceId,
+		Filter:   filter,
+		PageSize: listPageSize,
+	}
+	if latestOnly {
+		request.PageSize = 1
+	}
+
+	var versions []interface{}
+	for {
+		log.Printf("[DEBUG] Calling ListResourceVersions RPC with: %v\n", request)
+		response, err := rode.ListResourceVersions(ctx, request)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		for _, version := range response.Versions {
+			versions = append(versions, map[string]interface{}{
+				"version": version.Version,
+				"names":   version.Names,
+				"created": formatProtoTimestamp(version.Created),
+			})
+		}
+
+		if latestOnly || response.NextPageToken == "" || len(response.Versions) == 0 {
+			break
+		}
+		request.PageToken = response.NextPageToken
+	}
+
+	log.Printf("[DEBUG] Found %d versions of resource %s\n", len(versions), resourceId)
+	if err := d.Set("versions", versions); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(dataSourceId(resourceId, filter, strconv.FormatBool(latestOnly)))
+
+	return nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceVersionsDataSource_basic(t *testing.T) {
+	collectorId := fmt.Sprintf("tf-acc-%s", strings.ToLower(fake.LetterN(10)))
+	resourceId := testAccResourceId()
+	firstVersion := testAccResourceVersionUri(resourceId)
+	secondVersion := testAccResourceVersionUri(resourceId)
+	config := testAccCollectorConfig(collectorId, "DISCOVERY")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProvidersFactory,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  testAccCreateOccurrence("rode_collector.test", firstVersion),
+			},
+			{
+				Config: config,
+				Check:  testAccCreateOccurrence("rode_collector.test", secondVersion),
+			},
+			{
+				Config: config + fmt.Sprintf(`
+data "rode_resource_versions" "all" {
+	resource_id = "%[1]s"
+}
+
+data "rode_resource_versions" "latest" {
+	resource_id = "%[1]s"
+	latest_only = true
+}
+`, resourceId),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.rode_resource_versions.all", "versions.#", "2"),
+					resource.TestCheckResourceAttr("data.rode_resource_versions.all", "versions.0.version", secondVersion),
+					resource.TestCheckResourceAttr("data.rode_resource_versions.all", "versions.1.version", firstVersion),
+					resource.TestCheckResourceAttr("data.rode_resource_versions.latest", "versions.#", "1"),
+					resource.TestCheckResourceAttr("data.rode_resource_versions.latest", "versions.0.version", secondVersion),
+					resource.TestCheckResourceAttrSet("data.rode_resource_versions.latest", "versions.0.created"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,141 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/rode/rode/proto/v1alpha1"
+)
+
+func dataSourceResources() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to list the resources known to Rode, such as Docker images or npm packages. Use the `rode_resource_versions` data source to list the versions of a resource.",
+		ReadContext: dataSourceResourcesRead,
+		Schema: map[string]*schema.Schema{
+			"filter": {
+				Description: "A CEL expression used to filter resources, e.g., `name.startsWith(\"harbor.liatr.io/rode/\")`",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"name_prefix": {
+				Description: "Only return resources whose name starts with this prefix. Combined with `filter` if both are set.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"type": {
+				Description:      "Only return resources of this type, e.g., `DOCKER` or `NPM`. Combined with `filter` if both are set.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(resourceTypes(), false)),
+			},
+			"resources": {
+				Description: "The resources that matched the filter",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"created": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceResourcesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rode := meta.(*rodeClient)
+	if err := rode.init(ctx); err != nil {
+		return diag.FromErr(err)
+	}
+
+	var namePrefixFilter, typeFilter string
+	if namePrefix := d.Get("name_prefix").(string); namePrefix != "" {
+		namePrefixFilter = fmt.Sprintf("name.startsWith(%q)", namePrefix)
+	}
+	if resourceType := d.Get("type").(string); resourceType != "" {
+		typeFilter = fmt.Sprintf("type == %q", resourceType)
+	}
+	filter := combineFilters(d.Get("filter").(string), namePrefixFilter, typeFilter)
+
+	request := &v1alpha1.ListResourcesRequest{
+		Filter:   filter,
+		PageSize: listPageSize,
+	}
+
+	var resources []interface{}
+	for {
+		log.Printf("[DEBUG] Calling ListResources RPC with: %v\n", request)
+		response, err := rode.ListResources(ctx, request)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		for _, resource := range response.Resources {
+			resources = append(resources, map[string]interface{}{
+				"id":      resource.Id,
+				"name":    resource.Name,
+				"type":    resource.Type.String(),
+				"created": formatProtoTimestamp(resource.Created),
+			})
+		}
+
+		if response.NextPageToken == "" || len(response.Resources) == 0 {
+			break
+		}
+		request.PageToken = response.NextPageToken
+	}
+
+	log.Printf("[DEBUG] Found %d resources\n", len(resources))
+	if err := d.Set("resources", resources); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(dataSourceId(filter))
+
+	return nil
+}
+
+func resourceTypes() []string {
+	var types []string
+	for resourceType, value := range v1alpha1.ResourceType_value {
+		if value != int32(v1alpha1.ResourceType_RESOURCE_TYPE_UNSPECIFIED) {
+			types = append(types, resourceType)
+		}
+	}
+	sort.Strings(types)
+
+	return types
+}
@@ -0,0 +1,58 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourcesDataSource_basic(t *testing.T) {
+	dataSourceName := "data.rode_resources.test"
+	collectorId := fmt.Sprintf("tf-acc-%s", strings.ToLower(fake.LetterN(10)))
+	resourceId := testAccResourceId()
+	config := testAccCollectorConfig(collectorId, "DISCOVERY")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProvidersFactory,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  testAccCreateOccurrence("rode_collector.test", testAccResourceVersionUri(resourceId)),
+			},
+			{
+				Config: config + fmt.Sprintf(`
+data "rode_resources" "test" {
+	name_prefix = "%s"
+	type        = "DOCKER"
+}
+`, resourceId),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "resources.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "resources.0.id", resourceId),
+					resource.TestCheckResourceAttr(dataSourceName, "resources.0.name", resourceId),
+					resource.TestCheckResourceAttr(dataSourceName, "resources.0.type", "DOCKER"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "resources.0.created"),
+				),
+			},
+		},
+	})
+}
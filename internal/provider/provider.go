@@ -17,9 +17,11 @@ package provider
 import (
 	"context"
 	"log"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/rode/rode/common"
 )
 
@@ -43,12 +45,89 @@ func New(version string) func() *schema.Provider {
 					Optional:    true,
 					DefaultFunc: schema.EnvDefaultFunc("RODE_DISABLE_TRANSPORT_SECURITY", false),
 				},
+				"ca_cert_file": {
+					Description:   "Path to a file with PEM-encoded CA certificates used to verify Rode's certificate, instead of the system's trust store. Can also be set with the `RODE_CA_CERT_FILE` environment variable.",
+					Type:          schema.TypeString,
+					Optional:      true,
+					DefaultFunc:   schema.EnvDefaultFunc("RODE_CA_CERT_FILE", ""),
+					ConflictsWith: []string{"ca_cert_pem"},
+				},
+				"ca_cert_pem": {
+					Description:   "PEM-encoded CA certificates used to verify Rode's certificate. Conflicts with `ca_cert_file`. Can also be set with the `RODE_CA_CERT_PEM` environment variable.",
+					Type:          schema.TypeString,
+					Optional:      true,
+					DefaultFunc:   schema.EnvDefaultFunc("RODE_CA_CERT_PEM", ""),
+					ConflictsWith: []string{"ca_cert_file"},
+				},
+				// options with RequiredWith or validation default to nil, since the SDK treats an empty string default as set
+				"client_cert": {
+					Description:  "PEM-encoded client certificate for mutual TLS, e.g., `file(\"client.crt\")`. Must be set with `client_key`. Can also be set with the `RODE_CLIENT_CERT` environment variable.",
+					Type:         schema.TypeString,
+					Optional:     true,
+					DefaultFunc:  schema.EnvDefaultFunc("RODE_CLIENT_CERT", nil),
+					RequiredWith: []string{"client_key"},
+				},
+				"client_key": {
+					Description:  "PEM-encoded private key for `client_cert`. Can also be set with the `RODE_CLIENT_KEY` environment variable.",
+					Type:         schema.TypeString,
+					Optional:     true,
+					DefaultFunc:  schema.EnvDefaultFunc("RODE_CLIENT_KEY", nil),
+					RequiredWith: []string{"client_cert"},
+					Sensitive:    true,
+				},
+				"tls_server_name": {
+					Description: "Overrides the server name used to verify Rode's certificate, which defaults to the hostname in `host`. Can also be set with the `RODE_TLS_SERVER_NAME` environment variable.",
+					Type:        schema.TypeString,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("RODE_TLS_SERVER_NAME", ""),
+				},
+				"tls_min_version": {
+					Description:      "The minimum TLS version for the connection to Rode, one of `1.0`, `1.1`, `1.2`, or `1.3`. Can also be set with the `RODE_TLS_MIN_VERSION` environment variable.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					DefaultFunc:      schema.EnvDefaultFunc("RODE_TLS_MIN_VERSION", nil),
+					ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"1.0", "1.1", "1.2", "1.3"}, false)),
+				},
 				"lazy_init": {
 					Description: "Defers instantiation of the Rode client until the first time the provider is used. This can be useful when provider config depends on other resources being applied.",
 					Type:        schema.TypeBool,
 					Optional:    true,
 					DefaultFunc: schema.EnvDefaultFunc("RODE_LAZY_INIT", false),
 				},
+				"request_timeout": {
+					Description:      "The deadline for each RPC to Rode, e.g., `30s`. Each retry has its own deadline. Set to `0s` to only use the timeouts of each resource. Can also be set with the `RODE_REQUEST_TIMEOUT` environment variable.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					DefaultFunc:      schema.EnvDefaultFunc("RODE_REQUEST_TIMEOUT", "1m"),
+					ValidateDiagFunc: durationValidateDiagFunc,
+				},
+				"retry_max_attempts": {
+					Description:      "The maximum number of attempts for an RPC that fails with a transient error, such as `Unavailable` while Rode is restarting. Only RPCs that are safe to repeat are retried. Set to 1 to disable retries. Can also be set with the `RODE_RETRY_MAX_ATTEMPTS` environment variable.",
+					Type:             schema.TypeInt,
+					Optional:         true,
+					DefaultFunc:      schema.EnvDefaultFunc("RODE_RETRY_MAX_ATTEMPTS", 4),
+					ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+				},
+				"retry_base_delay": {
+					Description:      "The delay before the first retry, which doubles with each attempt up to 10 seconds. A random jitter is applied to each delay. Can also be set with the `RODE_RETRY_BASE_DELAY` environment variable.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					DefaultFunc:      schema.EnvDefaultFunc("RODE_RETRY_BASE_DELAY", "500ms"),
+					ValidateDiagFunc: durationValidateDiagFunc,
+				},
+				"wait_for_ready_timeout": {
+					Description:      "How long to keep trying to connect to Rode before giving up, e.g., `5m`. Useful when Rode is created earlier in the same apply, along with `lazy_init`. By default, the provider tries to connect once, and tries again on the next operation after a backoff. Can also be set with the `RODE_WAIT_FOR_READY_TIMEOUT` environment variable.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					DefaultFunc:      schema.EnvDefaultFunc("RODE_WAIT_FOR_READY_TIMEOUT", "0s"),
+					ValidateDiagFunc: durationValidateDiagFunc,
+				},
+				"disable_policy_validation": {
+					Description: "Skips validating planned `rode_policy` changes with Rode's ValidatePolicy RPC. Useful when the Rode instance isn't reachable during plan, such as with `lazy_init`. Can also be set with the `RODE_DISABLE_POLICY_VALIDATION` environment variable.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("RODE_DISABLE_POLICY_VALIDATION", false),
+				},
 				"oidc_client_id": {
 					Description: "OIDC/OAuth2 client id that is permitted the client credentials grant. Can be set with the `RODE_OIDC_CLIENT_ID` environment variable.",
 					Type:        schema.TypeString,
@@ -93,11 +172,44 @@ func New(version string) func() *schema.Provider {
 					DefaultFunc: schema.EnvDefaultFunc("RODE_BASIC_PASSWORD", ""),
 					Sensitive:   true,
 				},
+				"token": {
+					Description: "A bearer token sent with every request to Rode, such as a short-lived access token issued to a CI job. Cannot be configured alongside any other authentication method. Can be set with the `RODE_TOKEN` environment variable.",
+					Type:        schema.TypeString,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("RODE_TOKEN", ""),
+					Sensitive:   true,
+				},
+				"token_command": {
+					Description: "A command and its arguments that print a JSON object with an `access_token` and an optional RFC 3339 `expires_at` timestamp, e.g., a CLI login helper. The token is sent as a bearer token, and the command is run again when the token expires. Cannot be configured alongside any other authentication method.",
+					Type:        schema.TypeList,
+					Optional:    true,
+					MinItems:    1,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
 			},
 			ResourcesMap: map[string]*schema.Resource{
-				"rode_policy_group":      resourcePolicyGroup(),
-				"rode_policy":            resourcePolicy(),
-				"rode_policy_assignment": resourcePolicyAssignment(),
+				"rode_collector":                resourceCollector(),
+				"rode_policy_group":             resourcePolicyGroup(),
+				"rode_policy":                   resourcePolicy(),
+				"rode_policy_assignment":        resourcePolicyAssignment(),
+				"rode_policy_bundle":            resourcePolicyBundle(),
+				"rode_policy_group_assignments": resourcePolicyGroupAssignments(),
+				"rode_resource_evaluation":      resourceResourceEvaluation(),
+			},
+			DataSourcesMap: map[string]*schema.Resource{
+				"rode_policy":               dataSourcePolicy(),
+				"rode_policies":             dataSourcePolicies(),
+				"rode_policy_group":         dataSourcePolicyGroup(),
+				"rode_policy_groups":        dataSourcePolicyGroups(),
+				"rode_policy_assignments":   dataSourcePolicyAssignments(),
+				"rode_policy_versions":      dataSourcePolicyVersions(),
+				"rode_resource_evaluation":  dataSourceResourceEvaluation(),
+				"rode_resource_evaluations": dataSourceResourceEvaluations(),
+				"rode_resources":            dataSourceResources(),
+				"rode_resource_versions":    dataSourceResourceVersions(),
+				"rode_occurrences":          dataSourceOccurrences(),
 			},
 		}
 
@@ -121,15 +233,70 @@ func New(version string) func() *schema.Provider {
 				},
 			}
 
+			tlsConfig, err := newTLSConfig(d)
+			if err != nil {
+				return nil, diag.FromErr(err)
+			}
+			if tlsConfig != nil {
+				if config.Rode.DisableTransportSecurity {
+					return nil, diag.Errorf("TLS options can't be set when disable_transport_security is enabled")
+				}
+
+				// the TLS connection is established by the dialer in tlsDialOption instead of gRPC's transport credentials
+				config.Rode.DisableTransportSecurity = true
+			}
+
+			var tokenCredentials *tokenAuth
+			token := d.Get("token").(string)
+			var tokenCommand []string
+			for _, arg := range d.Get("token_command").([]interface{}) {
+				tokenCommand = append(tokenCommand, arg.(string))
+			}
+			if token != "" || len(tokenCommand) != 0 {
+				if config.OIDCAuth.ClientID != "" || config.BasicAuth.Username != "" {
+					return nil, diag.Errorf("only one authentication method can be used")
+				}
+
+				tokenCredentials, err = newTokenAuth(token, tokenCommand, config.Rode.DisableTransportSecurity)
+				if err != nil {
+					return nil, diag.FromErr(err)
+				}
+			}
+
+			retryBaseDelay, err := time.ParseDuration(d.Get("retry_base_delay").(string))
+			if err != nil {
+				return nil, diag.Errorf("invalid retry_base_delay: %s", err)
+			}
+
+			requestTimeout, err := time.ParseDuration(d.Get("request_timeout").(string))
+			if err != nil {
+				return nil, diag.Errorf("invalid request_timeout: %s", err)
+			}
+
+			waitForReadyTimeout, err := time.ParseDuration(d.Get("wait_for_ready_timeout").(string))
+			if err != nil {
+				return nil, diag.Errorf("invalid wait_for_ready_timeout: %s", err)
+			}
+
 			rodeClient := &rodeClient{
-				config:    config,
-				userAgent: provider.UserAgent("terraform-provider-rode", version),
+				config:           config,
+				tlsConfig:        tlsConfig,
+				tokenCredentials: tokenCredentials,
+				retry: &retryConfig{
+					maxAttempts: d.Get("retry_max_attempts").(int),
+					baseDelay:   retryBaseDelay,
+				},
+				requestTimeout:      requestTimeout,
+				waitForReadyTimeout: waitForReadyTimeout,
+				userAgent:           provider.UserAgent("terraform-provider-rode", version),
+
+				disablePolicyValidation: d.Get("disable_policy_validation").(bool),
 			}
 
 			lazyInit := d.Get("lazy_init").(bool)
 			if !lazyInit {
 				log.Println("[DEBUG] Lazy initialization is disabled, instantiating Rode client immediately")
-				if err := rodeClient.init(); err != nil {
+				if err := rodeClient.init(ctx); err != nil {
 					return nil, diag.FromErr(err)
 				}
 			}
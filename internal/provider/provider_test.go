@@ -15,12 +15,23 @@
 package provider
 
 import (
+	"context"
+	"crypto/sha256"
 	_ "embed"
+	"encoding/hex"
+	"fmt"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/brianvoe/gofakeit/v6"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/rode/rode/proto/v1alpha1"
+	"github.com/rode/rode/protodeps/grafeas/proto/v1beta1/common_go_proto"
+	"github.com/rode/rode/protodeps/grafeas/proto/v1beta1/discovery_go_proto"
+	grafeas_proto "github.com/rode/rode/protodeps/grafeas/proto/v1beta1/grafeas_go_proto"
 )
 
 var (
@@ -48,3 +59,55 @@ func testAccPreCheck(t *testing.T) {
 		t.Fatal("RODE_HOST must be set for acceptance tests")
 	}
 }
+
+func testAccResourceUri() string {
+	return testAccResourceVersionUri(testAccResourceId())
+}
+
+// testAccResourceId returns the id of a new Docker image resource
+func testAccResourceId() string {
+	return fmt.Sprintf("https://harbor.liatr.io/rode/tf-acc-%s", strings.ToLower(fake.LetterN(10)))
+}
+
+// testAccResourceVersionUri returns the URI of a new version of the Docker image resource
+func testAccResourceVersionUri(resourceId string) string {
+	return fmt.Sprintf("%s@sha256:%s", resourceId, testAccImageDigest())
+}
+
+// testAccImageDigest returns a random sha256 digest for a Docker image version
+func testAccImageDigest() string {
+	digest := sha256.Sum256([]byte(fake.LetterN(10)))
+
+	return hex.EncodeToString(digest[:])
+}
+
+func testAccCreateOccurrence(collectorResourceName, resourceUri string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		collector, ok := s.RootModule().Resources[collectorResourceName]
+		if !ok {
+			return fmt.Errorf("collector not found in state: %s", collectorResourceName)
+		}
+
+		rodeClient := testAccProvider.Meta().(*rodeClient)
+		_, err := rodeClient.BatchCreateOccurrences(context.Background(), &v1alpha1.BatchCreateOccurrencesRequest{
+			Occurrences: []*grafeas_proto.Occurrence{
+				{
+					Resource: &grafeas_proto.Resource{
+						Uri: resourceUri,
+					},
+					NoteName: collector.Primary.Attributes["note_names.DISCOVERY"],
+					Kind:     common_go_proto.NoteKind_DISCOVERY,
+					Details: &grafeas_proto.Occurrence_Discovered{
+						Discovered: &discovery_go_proto.Details{
+							Discovered: &discovery_go_proto.Discovered{
+								AnalysisStatus: discovery_go_proto.Discovered_FINISHED_SUCCESS,
+							},
+						},
+					},
+				},
+			},
+		})
+
+		return err
+	}
+}
@@ -0,0 +1,439 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/format"
+	"github.com/open-policy-agent/opa/tester"
+)
+
+const (
+	// Rode compiles policies as a module with this name, so it prefixes every error location
+	rodeValidationModuleName = "validate_module"
+	// test_content is compiled alongside the policy as a separate module, so failures can be located in the tests
+	regoTestModuleName = "test_module"
+)
+
+var (
+	regoErrorLocationRegexp = regexp.MustCompile(`^(?:\d+ errors? occurred:\s*)?` + rodeValidationModuleName + `:(\d+): (.+)$`)
+	// the keys Rode expects in each result of the violations set, see data/minimal.rego
+	rodeViolationKeys = []string{"id", "name", "pass", "message", "description"}
+
+	regoContentValidateDiagFunc = func(v interface{}, p cty.Path) diag.Diagnostics {
+		return validateRodePolicyRego(v.(string), p)
+	}
+	regoContentDiffSuppressFunc = func(_, old, new string, _ *schema.ResourceData) bool {
+		return regoEquivalent(old, new)
+	}
+)
+
+// regoError is a single Rego parse or compile error, located by a 1-based row and column in the policy
+type regoError struct {
+	row     int
+	col     int
+	message string
+}
+
+func (e *regoError) Error() string {
+	switch {
+	case e.row == 0:
+		return e.message
+	case e.col == 0:
+		return fmt.Sprintf("line %d: %s", e.row, e.message)
+	default:
+		return fmt.Sprintf("line %d, column %d: %s", e.row, e.col, e.message)
+	}
+}
+
+// parseRegoErrors converts the error messages returned by the ValidatePolicy RPC into located errors.
+// OPA only reports the row of an error, but parse errors are followed by the offending line and a caret under the column.
+func parseRegoErrors(regoContent string, messages []string) []*regoError {
+	sourceLines := strings.Split(regoContent, "\n")
+	var errs []*regoError
+
+	for _, message := range messages {
+		var current *regoError
+		for _, line := range strings.Split(message, "\n") {
+			if strings.TrimSpace(line) == "" || (strings.HasSuffix(line, "errors occurred:") && current == nil) {
+				continue
+			}
+
+			if match := regoErrorLocationRegexp.FindStringSubmatch(line); match != nil {
+				row, _ := strconv.Atoi(match[1])
+				current = &regoError{row: row, message: match[2]}
+				errs = append(errs, current)
+				continue
+			}
+
+			if current == nil {
+				current = &regoError{message: strings.TrimSpace(line)}
+				errs = append(errs, current)
+				continue
+			}
+
+			detail := strings.TrimPrefix(line, "\t")
+			if caret := strings.TrimRight(detail, " "); strings.HasSuffix(caret, "^") && strings.TrimSpace(caret) == "^" {
+				current.col = len(caret) + leadingTabs(sourceLines, current.row)
+			}
+		}
+	}
+
+	return errs
+}
+
+// leadingTabs counts the tabs OPA strips from a source line before printing it in an error
+func leadingTabs(sourceLines []string, row int) int {
+	if row < 1 || row > len(sourceLines) {
+		return 0
+	}
+	line := sourceLines[row-1]
+
+	return len(line) - len(strings.TrimLeft(line, "\t"))
+}
+
+func formatRegoErrors(errs []*regoError) string {
+	var messages []string
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+// validateRodePolicyRego compiles a policy with the embedded OPA compiler and checks that it has the shape Rode evaluates.
+// Parse and compile errors or missing rules are errors, while violations that don't look like Rode results are warnings.
+func validateRodePolicyRego(regoContent string, p cty.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	module, errs := compileRego(regoContent)
+	for _, err := range errs {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       "Invalid Rego",
+			Detail:        err.Error(),
+			AttributePath: p,
+		})
+	}
+
+	if module == nil {
+		return diags
+	}
+
+	errs, warnings := lintRodePolicy(module)
+	for _, err := range errs {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       "Rego policy is missing a rule required by Rode",
+			Detail:        err.Error(),
+			AttributePath: p,
+		})
+	}
+
+	for _, warning := range warnings {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Warning,
+			Summary:       "Rego policy violations may not be evaluated by Rode",
+			Detail:        warning.Error(),
+			AttributePath: p,
+		})
+	}
+
+	return diags
+}
+
+// regoEquivalent reports whether two policies have the same AST once formatted,
+// so that whitespace, formatting, and comment changes don't create a new policy version.
+// DiffSuppressFunc isn't applied to values set or compared during CustomizeDiff, so CustomizeDiff functions call this directly.
+func regoEquivalent(old, new string) bool {
+	if old == new {
+		return true
+	}
+
+	if old == "" || new == "" {
+		return false
+	}
+
+	oldModule, err := parseFormattedRego(old)
+	if err != nil {
+		return false
+	}
+
+	newModule, err := parseFormattedRego(new)
+	if err != nil {
+		return false
+	}
+
+	return oldModule.Equal(newModule)
+}
+
+func parseFormattedRego(regoContent string) (*ast.Module, error) {
+	formatted, err := format.Source(rodeValidationModuleName, []byte(regoContent))
+	if err != nil {
+		return nil, err
+	}
+
+	module, err := ast.ParseModule(rodeValidationModuleName, string(formatted))
+	if err != nil {
+		return nil, err
+	}
+
+	if module == nil {
+		return nil, fmt.Errorf("policy is empty")
+	}
+
+	return module, nil
+}
+
+// readRegoFile loads a policy from disk and runs the same local checks that are applied to rego_content
+func readRegoFile(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	regoContent := string(content)
+	var messages []string
+	for _, d := range validateRodePolicyRego(regoContent, nil) {
+		if d.Severity == diag.Error {
+			messages = append(messages, fmt.Sprintf("%s: %s", d.Summary, d.Detail))
+			continue
+		}
+
+		log.Printf("[WARN] %s: %s: %s\n", path, d.Summary, d.Detail)
+	}
+
+	if len(messages) > 0 {
+		return "", fmt.Errorf("%s failed validation:\n%s", path, strings.Join(messages, "\n"))
+	}
+
+	return regoContent, nil
+}
+
+// regoPackageName returns the package of a policy without the data prefix, e.g., "package foo.bar" is foo.bar
+func regoPackageName(regoContent string) (string, error) {
+	module, err := ast.ParseModule(rodeValidationModuleName, regoContent)
+	if err != nil {
+		return "", err
+	}
+
+	if module == nil {
+		return "", fmt.Errorf("policy is empty")
+	}
+
+	return strings.TrimPrefix(module.Package.Path.String(), ast.DefaultRootDocument.String()+"."), nil
+}
+
+func regoSourceHash(regoContent string) string {
+	hash := sha256.Sum256([]byte(regoContent))
+
+	return hex.EncodeToString(hash[:])
+}
+
+// compileRego parses and compiles a single policy. The module is nil if the policy couldn't be parsed.
+func compileRego(regoContent string) (*ast.Module, []*regoError) {
+	module, err := ast.ParseModule(rodeValidationModuleName, regoContent)
+	if err != nil {
+		return nil, astRegoErrors(err)
+	}
+
+	if module == nil {
+		return nil, []*regoError{{message: "policy is empty"}}
+	}
+
+	compiler := ast.NewCompiler()
+	if compiler.Compile(map[string]*ast.Module{rodeValidationModuleName: module}); compiler.Failed() {
+		return module, astRegoErrors(compiler.Errors)
+	}
+
+	return module, nil
+}
+
+func astRegoErrors(err error) []*regoError {
+	astErrors, ok := err.(ast.Errors)
+	if !ok {
+		return []*regoError{{message: err.Error()}}
+	}
+
+	var errs []*regoError
+	for _, astError := range astErrors {
+		errs = append(errs, newRegoError(astError.Location, fmt.Sprintf("%s: %s", astError.Code, astError.Message)))
+	}
+
+	return errs
+}
+
+func newRegoError(location *ast.Location, message string) *regoError {
+	if location == nil {
+		return &regoError{message: message}
+	}
+
+	return &regoError{
+		row:     location.Row,
+		col:     location.Col,
+		message: message,
+	}
+}
+
+// lintRodePolicy checks for the pass and violations rules that Rode reads when evaluating a policy
+func lintRodePolicy(module *ast.Module) (errs []*regoError, warnings []*regoError) {
+	if len(module.RuleSet("pass")) == 0 {
+		errs = append(errs, newRegoError(module.Package.Location, `policy must contain a "pass" rule`))
+	}
+
+	violations := module.RuleSet("violations")
+	if len(violations) == 0 {
+		errs = append(errs, newRegoError(module.Package.Location, `policy must contain a "violations" rule`))
+	}
+
+	for _, rule := range violations {
+		if rule.Head.Key == nil {
+			warnings = append(warnings, newRegoError(rule.Location, `"violations" should be a set of results, e.g., violations[result] { ... }`))
+			continue
+		}
+
+		result := violationResultObject(rule)
+		if result == nil {
+			warnings = append(warnings, newRegoError(rule.Location, `unable to find the object assigned to the "violations" result`))
+			continue
+		}
+
+		if missing := missingObjectKeys(result, rodeViolationKeys); len(missing) > 0 {
+			warnings = append(warnings, newRegoError(rule.Location, fmt.Sprintf(`"violations" result is missing keys: %s`, strings.Join(missing, ", "))))
+		}
+	}
+
+	return errs, warnings
+}
+
+// violationResultObject finds the object literal used as the key of a violations rule,
+// either directly in the rule head or assigned to the key variable in the rule body
+func violationResultObject(rule *ast.Rule) ast.Object {
+	if object, ok := rule.Head.Key.Value.(ast.Object); ok {
+		return object
+	}
+
+	key, ok := rule.Head.Key.Value.(ast.Var)
+	if !ok {
+		return nil
+	}
+
+	for _, expr := range rule.Body {
+		if !expr.IsAssignment() && !expr.IsEquality() {
+			continue
+		}
+
+		operands := expr.Operands()
+		if len(operands) != 2 {
+			continue
+		}
+
+		for i, operand := range operands {
+			if v, ok := operand.Value.(ast.Var); ok && v.Equal(key) {
+				if object, ok := operands[1-i].Value.(ast.Object); ok {
+					return object
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func missingObjectKeys(object ast.Object, keys []string) []string {
+	var missing []string
+	for _, key := range keys {
+		if object.Get(ast.StringTerm(key)) == nil {
+			missing = append(missing, key)
+		}
+	}
+
+	return missing
+}
+
+// runRegoTests evaluates the test_ rules in testContent against the policy with the embedded OPA test runner.
+// It returns an error for each test that failed, along with any errors parsing or compiling the tests.
+func runRegoTests(ctx context.Context, regoContent, testContent string) ([]*regoError, error) {
+	policyModule, err := ast.ParseModule(rodeValidationModuleName, regoContent)
+	if err != nil || policyModule == nil {
+		// invalid policies are reported by the rego_content validation instead
+		return nil, nil
+	}
+
+	testModule, err := ast.ParseModule(regoTestModuleName, testContent)
+	if err != nil {
+		return astRegoErrors(err), nil
+	}
+
+	if testModule == nil {
+		return []*regoError{{message: "tests are empty"}}, nil
+	}
+
+	runner := tester.NewRunner().
+		SetCompiler(ast.NewCompiler()).
+		EnableFailureLine(true).
+		SetModules(map[string]*ast.Module{
+			rodeValidationModuleName: policyModule,
+			regoTestModuleName:       testModule,
+		})
+
+	results, err := runner.RunTests(ctx, nil)
+	if err != nil {
+		if astErrors, ok := err.(ast.Errors); ok {
+			return astRegoErrors(astErrors), nil
+		}
+
+		return nil, err
+	}
+
+	var errs []*regoError
+	testCount := 0
+	for result := range results {
+		testCount++
+		switch {
+		case result.Error != nil:
+			errs = append(errs, newRegoError(result.Location, fmt.Sprintf("%s: %s", result.Name, result.Error)))
+		case result.Fail:
+			errs = append(errs, regoTestFailure(result))
+		}
+	}
+
+	if testCount == 0 {
+		errs = append(errs, newRegoError(testModule.Package.Location, "tests must contain at least one test_ rule"))
+	}
+
+	return errs, nil
+}
+
+// regoTestFailure locates a failed test at the expression that failed, when the OPA tracer found one in the tests
+func regoTestFailure(result *tester.Result) *regoError {
+	if result.FailedAt == nil || result.FailedAt.Location == nil || result.FailedAt.Location.File != regoTestModuleName {
+		return newRegoError(result.Location, fmt.Sprintf("%s failed", result.Name))
+	}
+
+	return newRegoError(result.FailedAt.Location, fmt.Sprintf("%s failed at %s", result.Name, result.FailedAt.Location.Text))
+}
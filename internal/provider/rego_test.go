@@ -0,0 +1,160 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/open-policy-agent/opa/ast"
+)
+
+func TestParseRegoErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		regoContent string
+		messages    []string
+		expected    []regoError
+	}{
+		{
+			name:        "compile errors after empty strings",
+			regoContent: "package foo\n\npass {\n\tfoo(1)\n\tbar(2)\n}\n",
+			messages: []string{
+				"",
+				"",
+				"validate_module:4: rego_type_error: undefined function foo",
+				"validate_module:5: rego_type_error: undefined function bar",
+			},
+			expected: []regoError{
+				{row: 4, message: "rego_type_error: undefined function foo"},
+				{row: 5, message: "rego_type_error: undefined function bar"},
+			},
+		},
+		{
+			name:        "single parse error with caret",
+			regoContent: "package foo\n\npass {\n\tx := [1,\n}\n",
+			messages: []string{
+				"1 error occurred: validate_module:5: rego_parse_error: unexpected } token\n\t}\n\t^",
+			},
+			expected: []regoError{
+				{row: 5, col: 1, message: "rego_parse_error: unexpected } token"},
+			},
+		},
+		{
+			name:        "caret under a tab-indented line",
+			regoContent: "package foo\n\npass {\n\t\tx = \n}\n",
+			messages: []string{
+				"1 error occurred: validate_module:4: rego_parse_error: unexpected eq token: expected \\n or ; or }\n\tx = \n\t  ^",
+			},
+			expected: []regoError{
+				{row: 4, col: 5, message: "rego_parse_error: unexpected eq token: expected \\n or ; or }"},
+			},
+		},
+		{
+			name:        "multiple errors in one message",
+			regoContent: "package foo\n\npass {\n\tx := [1,\n}\n\nviolations {\n\t\ty = \n}\n",
+			messages: []string{
+				"2 errors occurred:\nvalidate_module:5: rego_parse_error: unexpected } token\n\t}\n\t^\nvalidate_module:8: rego_parse_error: unexpected eq token\n\ty = \n\t  ^",
+			},
+			expected: []regoError{
+				{row: 5, col: 1, message: "rego_parse_error: unexpected } token"},
+				{row: 8, col: 5, message: "rego_parse_error: unexpected eq token"},
+			},
+		},
+		{
+			name:        "error without a location",
+			regoContent: "package foo\n",
+			messages:    []string{"policy must contain a \"pass\" rule"},
+			expected: []regoError{
+				{message: "policy must contain a \"pass\" rule"},
+			},
+		},
+		{
+			name:        "only empty strings",
+			regoContent: "package foo\n",
+			messages:    []string{"", ""},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var actual []regoError
+			for _, err := range parseRegoErrors(tc.regoContent, tc.messages) {
+				actual = append(actual, *err)
+			}
+
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("expected %+v, got %+v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+// TestParseRegoErrors_matchesCompileRego checks that errors from the ValidatePolicy RPC are located at the same
+// position as the errors from the embedded compiler
+func TestParseRegoErrors_matchesCompileRego(t *testing.T) {
+	policies := map[string]string{
+		"parse error":                "package foo\n\npass {\n\tx := [1,\n}\n",
+		"indented parse error":       "package foo\n\npass {\n\t\tx = \n}\n",
+		"space indented parse error": "package foo\n\npass {\n    x = \n}\n",
+		"compile error":              "package foo\n\npass {\n\tfoo(1)\n}\n",
+	}
+
+	for name, regoContent := range policies {
+		t.Run(name, func(t *testing.T) {
+			module, expected := compileRego(regoContent)
+			if len(expected) == 0 {
+				t.Fatal("expected policy to be invalid")
+			}
+
+			actual := parseRegoErrors(regoContent, rodeValidatePolicyErrors(t, regoContent))
+			if len(actual) != len(expected) {
+				t.Fatalf("expected %d errors, got %d: %s", len(expected), len(actual), formatRegoErrors(actual))
+			}
+
+			for i := range expected {
+				// only parse errors are followed by a caret, so compile errors aren't located by column
+				expectedCol := 0
+				if module == nil {
+					expectedCol = expected[i].col
+				}
+
+				if actual[i].row != expected[i].row || actual[i].col != expectedCol {
+					t.Errorf("expected error at %d:%d, got %d:%d", expected[i].row, expectedCol, actual[i].row, actual[i].col)
+				}
+			}
+		})
+	}
+}
+
+// rodeValidatePolicyErrors builds the Errors field of a ValidatePolicy response the same way Rode does
+func rodeValidatePolicyErrors(t *testing.T, regoContent string) []string {
+	module, err := ast.ParseModule(rodeValidationModuleName, regoContent)
+	if err != nil {
+		return []string{err.Error()}
+	}
+
+	compiler := ast.NewCompiler()
+	if compiler.Compile(map[string]*ast.Module{rodeValidationModuleName: module}); !compiler.Failed() {
+		t.Fatal("expected policy to fail compilation")
+	}
+
+	errs := make([]string, len(compiler.Errors))
+	for i := range compiler.Errors {
+		errs = append(errs, compiler.Errors[i].Error())
+	}
+
+	return errs
+}
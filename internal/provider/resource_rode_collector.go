@@ -0,0 +1,198 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/rode/rode/proto/v1alpha1"
+	"github.com/rode/rode/protodeps/grafeas/proto/v1beta1/common_go_proto"
+	grafeas_proto "github.com/rode/rode/protodeps/grafeas/proto/v1beta1/grafeas_go_proto"
+)
+
+var noteKindValidateDiagFunc = validation.ToDiagFunc(validation.StringInSlice(noteKinds(), false))
+
+func resourceCollector() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Registers a collector and the notes it references when creating occurrences, using the RegisterCollector RPC. Rode only creates notes that don't exist yet, so changing the description of an existing note has no effect. Rode has no API for deleting notes, so destroying this resource only removes it from the Terraform state.",
+		CreateContext: resourceCollectorCreate,
+		ReadContext:   resourceCollectorRead,
+		UpdateContext: resourceCollectorUpdate,
+		DeleteContext: resourceCollectorDelete,
+		Timeouts:      defaultResourceTimeouts(),
+		CustomizeDiff: customdiff.Sequence(
+			resourceCollectorValidateNoteKinds,
+			func(ctx context.Context, diff *schema.ResourceDiff, i interface{}) error {
+				if diff.HasChange("note") {
+					return diff.SetNewComputed("note_names")
+				}
+
+				return nil
+			},
+		),
+		Schema: map[string]*schema.Schema{
+			"collector_id": {
+				Description: "Unique identifier of the collector, used as the prefix of its note ids",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"note": {
+				Description: "A note the collector references. Each note must have a different kind.",
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"kind": {
+							Description:      "The kind of note, e.g., `VULNERABILITY` or `BUILD`",
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: noteKindValidateDiagFunc,
+						},
+						"short_description": {
+							Description: "A one sentence description of the note",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"long_description": {
+							Description: "A detailed description of the note",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"note_names": {
+				Description: "The fully qualified names of the collector's notes, keyed by note kind",
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourceCollectorCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rode := meta.(*rodeClient)
+	if err := rode.init(ctx); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := registerCollector(ctx, d, rode); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(d.Get("collector_id").(string))
+
+	return resourceCollectorRead(ctx, d, meta)
+}
+
+// resourceCollectorRead keeps the registered notes in state, since Rode has no RPC for reading a collector's notes
+func resourceCollectorRead(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	return nil
+}
+
+func resourceCollectorUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rode := meta.(*rodeClient)
+	if err := rode.init(ctx); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := registerCollector(ctx, d, rode); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceCollectorRead(ctx, d, meta)
+}
+
+func resourceCollectorDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Removing collector %s from state, Rode doesn't support deleting notes\n", d.Id())
+
+	return nil
+}
+
+// resourceCollectorValidateNoteKinds fails the plan when two notes have the same kind, which RegisterCollector rejects
+func resourceCollectorValidateNoteKinds(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	kinds := map[string]int{}
+	for i, n := range diff.Get("note").([]interface{}) {
+		note, ok := n.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		kind, _ := note["kind"].(string)
+		if kind == "" {
+			// unknown kinds are checked during apply
+			continue
+		}
+
+		if first, ok := kinds[kind]; ok {
+			return cty.GetAttrPath("note").IndexInt(i).GetAttr("kind").NewErrorf("note %d has the same kind as note %d, %s; each note must have a different kind", i, first, kind)
+		}
+		kinds[kind] = i
+	}
+
+	return nil
+}
+
+func registerCollector(ctx context.Context, d *schema.ResourceData, rode *rodeClient) error {
+	request := &v1alpha1.RegisterCollectorRequest{
+		Id: d.Get("collector_id").(string),
+	}
+
+	for _, n := range d.Get("note").([]interface{}) {
+		note := n.(map[string]interface{})
+		request.Notes = append(request.Notes, &grafeas_proto.Note{
+			Kind:             common_go_proto.NoteKind(common_go_proto.NoteKind_value[note["kind"].(string)]),
+			ShortDescription: note["short_description"].(string),
+			LongDescription:  note["long_description"].(string),
+		})
+	}
+
+	log.Printf("[DEBUG] Calling RegisterCollector RPC with: %v\n", request)
+	response, err := rode.RegisterCollector(ctx, request)
+	if err != nil {
+		return err
+	}
+	log.Printf("[DEBUG] Successfully registered collector: %v\n", response)
+
+	noteNames := map[string]interface{}{}
+	for _, note := range response.Notes {
+		noteNames[note.Kind.String()] = note.Name
+	}
+
+	return d.Set("note_names", noteNames)
+}
+
+func noteKinds() []string {
+	var kinds []string
+	for kind, value := range common_go_proto.NoteKind_value {
+		if value != int32(common_go_proto.NoteKind_NOTE_KIND_UNSPECIFIED) {
+			kinds = append(kinds, kind)
+		}
+	}
+	sort.Strings(kinds)
+
+	return kinds
+}
@@ -0,0 +1,131 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccCollector_basic(t *testing.T) {
+	resourceName := "rode_collector.test"
+	collectorId := fmt.Sprintf("tf-acc-%s", strings.ToLower(fake.LetterN(10)))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProvidersFactory,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCollectorConfig(collectorId, "VULNERABILITY"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", collectorId),
+					resource.TestCheckResourceAttr(resourceName, "note_names.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "note_names.VULNERABILITY", fmt.Sprintf("projects/rode/notes/%s-vulnerability", collectorId)),
+				),
+			},
+			{
+				Config: testAccCollectorConfig(collectorId, "VULNERABILITY", "BUILD"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "note_names.%", "2"),
+					resource.TestCheckResourceAttr(resourceName, "note_names.BUILD", fmt.Sprintf("projects/rode/notes/%s-build", collectorId)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCollector_duplicate_note_kinds(t *testing.T) {
+	collectorId := fmt.Sprintf("tf-acc-%s", strings.ToLower(fake.LetterN(10)))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProvidersFactory,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCollectorConfig(collectorId, "BUILD", "VULNERABILITY", "BUILD"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("each note must have a different kind"),
+			},
+		},
+	})
+}
+
+func TestResourceCollectorDiff_duplicateNoteKinds(t *testing.T) {
+	tests := map[string]struct {
+		kinds         []string
+		expectedError string
+	}{
+		"different kinds": {
+			kinds: []string{"BUILD", "VULNERABILITY"},
+		},
+		"duplicate kinds": {
+			kinds:         []string{"BUILD", "VULNERABILITY", "BUILD"},
+			expectedError: "note 2 has the same kind as note 0, BUILD",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var notes []interface{}
+			for _, kind := range tc.kinds {
+				notes = append(notes, map[string]interface{}{"kind": kind})
+			}
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{
+				"collector_id": "collector",
+				"note":         notes,
+			})
+
+			_, err := resourceCollector().SimpleDiff(context.Background(), nil, config, nil)
+			if tc.expectedError == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+				t.Errorf("expected error %q, got %v", tc.expectedError, err)
+			}
+		})
+	}
+}
+
+func testAccCollectorConfig(collectorId string, kinds ...string) string {
+	var notes []string
+	for _, kind := range kinds {
+		notes = append(notes, fmt.Sprintf(`
+	note {
+		kind              = "%s"
+		short_description = "Terraform acceptance test"
+	}`, kind))
+	}
+
+	return fmt.Sprintf(`
+resource "rode_collector" "test" {
+	collector_id = "%s"
+%s
+}
+`, collectorId, strings.Join(notes, "\n"))
+}
@@ -19,10 +19,14 @@ import (
 	"fmt"
 	"log"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/go-uuid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/rode/rode/proto/v1alpha1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func resourcePolicy() *schema.Resource {
@@ -32,16 +36,26 @@ func resourcePolicy() *schema.Resource {
 		ReadContext:   resourcePolicyRead,
 		UpdateContext: resourcePolicyUpdate,
 		DeleteContext: resourcePolicyDelete,
+		Timeouts:      defaultResourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			StateContext: resourcePolicyImport,
 		},
-		CustomizeDiff: func(ctx context.Context, diff *schema.ResourceDiff, i interface{}) error {
-			if diff.HasChange("rego_content") {
-				return diff.SetNewComputed("policy_version_id")
-			}
+		CustomizeDiff: customdiff.Sequence(
+			resourcePolicyLoadRegoFile,
+			resourcePolicyValidateRego,
+			resourcePolicyRunTests,
+			func(ctx context.Context, diff *schema.ResourceDiff, i interface{}) error {
+				if regoContentChanged(diff) {
+					if err := diff.SetNewComputed("source_hash"); err != nil {
+						return err
+					}
 
-			return nil
-		},
+					return diff.SetNewComputed("policy_version_id")
+				}
+
+				return nil
+			},
+		),
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Description: "Policy name",
@@ -70,9 +84,29 @@ func resourcePolicy() *schema.Resource {
 				Computed:    true,
 			},
 			"rego_content": {
-				Description: "The Rego code",
+				Description:      "The Rego code. It's compiled locally to check that it has the `pass` and `violations` rules that Rode requires. Changes that don't affect the parsed policy, like whitespace, formatting, or comments, are ignored and don't create a new policy version. Exactly one of `rego_content` or `rego_file` must be set.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ExactlyOneOf:     []string{"rego_content", "rego_file"},
+				ValidateDiagFunc: regoContentValidateDiagFunc,
+				DiffSuppressFunc: regoContentDiffSuppressFunc,
+			},
+			"rego_file": {
+				Description:  "Path to a file containing the Rego code, as an alternative to `rego_content`. The file is read when planning, and `rego_content` is updated when its contents change.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"rego_content", "rego_file"},
+			},
+			"source_hash": {
+				Description: "SHA-256 hash of `rego_content`",
+				Computed:    true,
 				Type:        schema.TypeString,
-				Required:    true,
+			},
+			"test_content": {
+				Description: "Rego unit tests for the policy. The `test_` rules are evaluated against `rego_content` with an embedded OPA runtime when planning, and any failing test fails the plan. The tests are only stored in the Terraform state and are never sent to Rode.",
+				Type:        schema.TypeString,
+				Optional:    true,
 			},
 			"created": {
 				Description: "Creation timestamp",
@@ -89,13 +123,31 @@ func resourcePolicy() *schema.Resource {
 				Computed:    true,
 				Type:        schema.TypeBool,
 			},
+			"keep_deleted": {
+				Description: "Keep the policy in state when it's soft-deleted outside of Terraform, instead of planning to recreate it. The `deleted` attribute shows whether it has been deleted.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"prevent_destroy_with_assignments": {
+				Description: "Refuse to delete the policy while policy assignments still reference it. Like `force_destroy`, this must be applied to the state before it takes effect on destroy.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"force_destroy": {
+				Description: "Delete any policy assignments that reference the policy before deleting it. Must be applied to the state before it takes effect on destroy.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
 		},
 	}
 }
 
 func resourcePolicyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	rode := meta.(*rodeClient)
-	if err := rode.init(); err != nil {
+	if err := rode.init(ctx); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -117,27 +169,41 @@ func resourcePolicyCreate(ctx context.Context, d *schema.ResourceData, meta inte
 
 	d.SetId(response.Id)
 
-	return resourcePolicyRead(ctx, d, meta)
+	return resourcePolicyRead(withReadAfterWrite(ctx), d, meta)
 }
 
 func resourcePolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	rode := meta.(*rodeClient)
-	if err := rode.init(); err != nil {
+	if err := rode.init(ctx); err != nil {
 		return diag.FromErr(err)
 	}
 
 	log.Println("[DEBUG] Calling GetPolicy RPC")
 	policy, err := rode.GetPolicy(ctx, &v1alpha1.GetPolicyRequest{Id: d.Id()})
 	if err != nil {
+		// a new policy may not be readable yet if Rode's index hasn't refreshed, so it's an error rather than a deletion
+		if status.Code(err) == codes.NotFound && !d.IsNewResource() {
+			log.Println("[DEBUG] Policy appears to have been deleted")
+			d.SetId("")
+			return nil
+		}
+
 		return diag.FromErr(err)
 	}
 
+	if policy.Deleted && !d.Get("keep_deleted").(bool) {
+		log.Printf("[WARN] Policy %s was deleted outside of Terraform, removing it from state\n", d.Id())
+		d.SetId("")
+		return nil
+	}
+
 	d.Set("name", policy.Name)
 	d.Set("description", policy.Description)
 	d.Set("current_version", policy.CurrentVersion)
 	d.Set("policy_version_id", policy.Policy.Id)
 	d.Set("message", policy.Policy.Message)
 	d.Set("rego_content", policy.Policy.RegoContent)
+	d.Set("source_hash", regoSourceHash(policy.Policy.RegoContent))
 	d.Set("created", formatProtoTimestamp(policy.Created))
 	d.Set("updated", formatProtoTimestamp(policy.Updated))
 	d.Set("deleted", policy.Deleted)
@@ -147,10 +213,15 @@ func resourcePolicyRead(ctx context.Context, d *schema.ResourceData, meta interf
 
 func resourcePolicyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	rode := meta.(*rodeClient)
-	if err := rode.init(); err != nil {
+	if err := rode.init(ctx); err != nil {
 		return diag.FromErr(err)
 	}
 
+	if !d.HasChanges("name", "description", "message", "rego_content") {
+		log.Println("[DEBUG] No attributes stored in Rode changed, skipping UpdatePolicy RPC")
+		return resourcePolicyRead(ctx, d, meta)
+	}
+
 	policy := &v1alpha1.Policy{
 		Id:          d.Id(),
 		Name:        d.Get("name").(string),
@@ -176,10 +247,14 @@ func resourcePolicyUpdate(ctx context.Context, d *schema.ResourceData, meta inte
 
 func resourcePolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	rode := meta.(*rodeClient)
-	if err := rode.init(); err != nil {
+	if err := rode.init(ctx); err != nil {
 		return diag.FromErr(err)
 	}
 
+	if diags := checkPolicyAssignmentsBeforeDelete(ctx, d, rode, &v1alpha1.ListPolicyAssignmentsRequest{PolicyId: d.Id()}, "policy"); diags.HasError() {
+		return diags
+	}
+
 	log.Println("[DEBUG] Calling DeletePolicy RPC")
 	_, err := rode.DeletePolicy(ctx, &v1alpha1.DeletePolicyRequest{
 		Id: d.Id(),
@@ -188,11 +263,125 @@ func resourcePolicyDelete(ctx context.Context, d *schema.ResourceData, meta inte
 	return diag.FromErr(err)
 }
 
+// resourcePolicyLoadRegoFile plans rego_content from the contents of rego_file, so that the other plan checks apply to it
+func resourcePolicyLoadRegoFile(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if !diff.NewValueKnown("rego_file") {
+		return diff.SetNewComputed("rego_content")
+	}
+
+	regoFile := diff.Get("rego_file").(string)
+	if regoFile == "" {
+		return nil
+	}
+
+	log.Printf("[DEBUG] Loading rego_content from %s\n", regoFile)
+	regoContent, err := readRegoFile(regoFile)
+	if err != nil {
+		return cty.GetAttrPath("rego_file").NewError(err)
+	}
+
+	if current, _ := diff.GetChange("rego_content"); regoEquivalent(current.(string), regoContent) {
+		return nil
+	}
+
+	return diff.SetNew("rego_content", regoContent)
+}
+
+// regoContentChanged reports whether the planned rego_content differs from the state once formatted,
+// unlike ResourceDiff.HasChange, which compares the raw values
+func regoContentChanged(diff *schema.ResourceDiff) bool {
+	if !diff.NewValueKnown("rego_content") {
+		return true
+	}
+
+	old, new := diff.GetChange("rego_content")
+
+	return !regoEquivalent(old.(string), new.(string))
+}
+
+// resourcePolicyValidateRego compiles planned Rego changes with the ValidatePolicy RPC,
+// so that invalid policies fail the plan instead of part way through an apply
+func resourcePolicyValidateRego(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	rode := meta.(*rodeClient)
+	if rode.disablePolicyValidation || !regoContentChanged(diff) || !diff.NewValueKnown("rego_content") {
+		return nil
+	}
+
+	if err := rode.init(ctx); err != nil {
+		return err
+	}
+
+	regoContent := diff.Get("rego_content").(string)
+	log.Println("[DEBUG] Calling ValidatePolicy RPC")
+	response, err := rode.ValidatePolicy(ctx, &v1alpha1.ValidatePolicyRequest{Policy: regoContent})
+	if err != nil {
+		validationResponse := validatePolicyResponseFromError(err)
+		if validationResponse == nil {
+			return err
+		}
+		response = validationResponse
+	}
+
+	if response.Compile {
+		return nil
+	}
+
+	regoErrors := parseRegoErrors(regoContent, response.Errors)
+	if len(regoErrors) == 0 {
+		return cty.GetAttrPath("rego_content").NewErrorf("policy failed validation")
+	}
+
+	return cty.GetAttrPath("rego_content").NewErrorf("policy failed validation:\n%s", formatRegoErrors(regoErrors))
+}
+
+// resourcePolicyRunTests runs the Rego unit tests in test_content against the planned policy
+func resourcePolicyRunTests(ctx context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if !(regoContentChanged(diff) || diff.HasChange("test_content")) || !diff.NewValueKnown("rego_content") || !diff.NewValueKnown("test_content") {
+		return nil
+	}
+
+	testContent := diff.Get("test_content").(string)
+	if testContent == "" {
+		return nil
+	}
+
+	log.Println("[DEBUG] Running Rego tests from test_content")
+	failures, err := runRegoTests(ctx, diff.Get("rego_content").(string), testContent)
+	if err != nil {
+		return err
+	}
+
+	if len(failures) == 0 {
+		return nil
+	}
+
+	return cty.GetAttrPath("test_content").NewErrorf("policy tests failed:\n%s", formatRegoErrors(failures))
+}
+
+// validatePolicyResponseFromError extracts the compilation errors that Rode attaches to an InvalidArgument status
+func validatePolicyResponseFromError(err error) *v1alpha1.ValidatePolicyResponse {
+	s, ok := status.FromError(err)
+	if !ok || s.Code() != codes.InvalidArgument {
+		return nil
+	}
+
+	for _, detail := range s.Details() {
+		if response, ok := detail.(*v1alpha1.ValidatePolicyResponse); ok {
+			return response
+		}
+	}
+
+	return nil
+}
+
 func resourcePolicyImport(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
 	policyId := d.Id()
 	if _, err := uuid.ParseUUID(policyId); err != nil {
 		return nil, fmt.Errorf("invalid policy id: %s", err)
 	}
+	d.Set("keep_deleted", false)
+	d.Set("prevent_destroy_with_assignments", false)
+	d.Set("force_destroy", false)
 
 	return []*schema.ResourceData{d}, nil
 }
@@ -16,14 +16,18 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/go-uuid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/rode/rode/proto/v1alpha1"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -35,6 +39,9 @@ var (
 
 		return diag.FromErr(err)
 	}
+	policyVersionSelectorRegexp           = regexp.MustCompile(`^(latest(-[0-9]+)?|[0-9]+)$`)
+	policyVersionSelectorMessage          = "version selectors must be 'latest', 'latest-N', or a version number"
+	policyVersionSelectorValidateDiagFunc = validation.ToDiagFunc(validation.StringMatch(policyVersionSelectorRegexp, policyVersionSelectorMessage))
 )
 
 func resourcePolicyAssignment() *schema.Resource {
@@ -44,16 +51,35 @@ func resourcePolicyAssignment() *schema.Resource {
 		ReadContext:   resourcePolicyAssignmentRead,
 		UpdateContext: resourcePolicyAssignmentUpdate,
 		DeleteContext: resourcePolicyAssignmentDelete,
+		Timeouts:      defaultResourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			StateContext: resourcePolicyAssignmentImport,
 		},
+		CustomizeDiff: resourcePolicyAssignmentCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"policy_version_id": {
-				Description:      "Unique identifier of the versioned policy",
-				Required:         true,
+				Description:      "Unique identifier of the versioned policy. Computed from `policy_id` and `version_selector` if those are set instead.",
+				Optional:         true,
+				Computed:         true,
 				Type:             schema.TypeString,
+				ExactlyOneOf:     []string{"policy_version_id", "policy_id"},
 				ValidateDiagFunc: policyVersionIdValidateDiagFunc,
 			},
+			"policy_id": {
+				Description:      "Unique identifier of the policy. Used with `version_selector` as an alternative to `policy_version_id`.",
+				Optional:         true,
+				ForceNew:         true,
+				Type:             schema.TypeString,
+				ExactlyOneOf:     []string{"policy_version_id", "policy_id"},
+				ValidateDiagFunc: policyIdValidateDiagFunc,
+			},
+			"version_selector": {
+				Description:      "Selects the version of `policy_id` to assign: `latest`, `latest-N` for the Nth version before the latest, or an exact version number. Defaults to `latest`. The selector is resolved when planning, so a version created in the same apply is picked up by the next plan.",
+				Optional:         true,
+				Type:             schema.TypeString,
+				RequiredWith:     []string{"policy_id"},
+				ValidateDiagFunc: policyVersionSelectorValidateDiagFunc,
+			},
 			"policy_group": {
 				Description:      "Name of the policy group to associate with the policy",
 				Required:         true,
@@ -77,11 +103,17 @@ func resourcePolicyAssignment() *schema.Resource {
 
 func resourcePolicyAssignmentCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	rode := meta.(*rodeClient)
-	if err := rode.init(); err != nil {
+	if err := rode.init(ctx); err != nil {
 		return diag.FromErr(err)
 	}
+
+	policyVersionId, err := policyAssignmentVersionId(ctx, rode, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	policyAssignment := &v1alpha1.PolicyAssignment{
-		PolicyVersionId: d.Get("policy_version_id").(string),
+		PolicyVersionId: policyVersionId,
 		PolicyGroup:     d.Get("policy_group").(string),
 	}
 
@@ -94,12 +126,12 @@ func resourcePolicyAssignmentCreate(ctx context.Context, d *schema.ResourceData,
 	log.Printf("[DEBUG] Successfully created policy assignment: %v\n", response)
 	d.SetId(response.Id)
 
-	return resourcePolicyAssignmentRead(ctx, d, meta)
+	return resourcePolicyAssignmentRead(withReadAfterWrite(ctx), d, meta)
 }
 
 func resourcePolicyAssignmentRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	rode := meta.(*rodeClient)
-	if err := rode.init(); err != nil {
+	if err := rode.init(ctx); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -126,13 +158,18 @@ func resourcePolicyAssignmentRead(ctx context.Context, d *schema.ResourceData, m
 
 func resourcePolicyAssignmentUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	rode := meta.(*rodeClient)
-	if err := rode.init(); err != nil {
+	if err := rode.init(ctx); err != nil {
+		return diag.FromErr(err)
+	}
+
+	policyVersionId, err := policyAssignmentVersionId(ctx, rode, d)
+	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	assignment := &v1alpha1.PolicyAssignment{
 		Id:              d.Id(),
-		PolicyVersionId: d.Get("policy_version_id").(string),
+		PolicyVersionId: policyVersionId,
 		PolicyGroup:     d.Get("policy_group").(string),
 	}
 	log.Printf("[DEBUG] Calling UpdatePolicyAssignment RPC with: %v\n", assignment)
@@ -147,7 +184,7 @@ func resourcePolicyAssignmentUpdate(ctx context.Context, d *schema.ResourceData,
 
 func resourcePolicyAssignmentDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	rode := meta.(*rodeClient)
-	if err := rode.init(); err != nil {
+	if err := rode.init(ctx); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -162,6 +199,94 @@ func resourcePolicyAssignmentDelete(ctx context.Context, d *schema.ResourceData,
 	return diag.FromErr(err)
 }
 
+func resourcePolicyAssignmentCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.NewValueKnown("policy_id") || !diff.NewValueKnown("version_selector") {
+		return diff.SetNewComputed("policy_version_id")
+	}
+
+	policyId := diff.Get("policy_id").(string)
+	if policyId == "" {
+		return nil
+	}
+
+	rode := meta.(*rodeClient)
+	if err := rode.init(ctx); err != nil {
+		return err
+	}
+
+	policyVersionId, err := resolvePolicyVersionId(ctx, rode, policyId, diff.Get("version_selector").(string))
+	if err != nil {
+		return err
+	}
+
+	if diff.Get("policy_version_id").(string) != policyVersionId {
+		log.Printf("[DEBUG] Version selector resolved to policy version %s\n", policyVersionId)
+		return diff.SetNew("policy_version_id", policyVersionId)
+	}
+
+	return nil
+}
+
+// policyAssignmentVersionId returns the planned policy version id, resolving the version selector if it was unknown during plan
+func policyAssignmentVersionId(ctx context.Context, rode *rodeClient, d *schema.ResourceData) (string, error) {
+	policyVersionId := d.Get("policy_version_id").(string)
+	policyId := d.Get("policy_id").(string)
+	if policyVersionId != "" || policyId == "" {
+		return policyVersionId, nil
+	}
+
+	return resolvePolicyVersionId(ctx, rode, policyId, d.Get("version_selector").(string))
+}
+
+func resolvePolicyVersionId(ctx context.Context, rode *rodeClient, policyId, selector string) (string, error) {
+	log.Println("[DEBUG] Calling GetPolicy RPC")
+	policy, err := rode.GetPolicy(ctx, &v1alpha1.GetPolicyRequest{Id: policyId})
+	if err != nil {
+		return "", err
+	}
+
+	version, err := selectPolicyVersion(selector, policy.CurrentVersion)
+	if err != nil {
+		return "", fmt.Errorf("unable to select a version of policy %s: %s", policyId, err)
+	}
+
+	return fmt.Sprintf("%s.%d", policyId, version), nil
+}
+
+func selectPolicyVersion(selector string, currentVersion uint32) (uint32, error) {
+	if selector == "" || selector == "latest" {
+		return currentVersion, nil
+	}
+
+	if !policyVersionSelectorRegexp.MatchString(selector) {
+		return 0, errors.New(policyVersionSelectorMessage)
+	}
+
+	if strings.HasPrefix(selector, "latest-") {
+		offset, err := strconv.Atoi(strings.TrimPrefix(selector, "latest-"))
+		if err != nil {
+			return 0, err
+		}
+
+		if offset >= int(currentVersion) {
+			return 0, fmt.Errorf("%s is before the first version, the latest version is %d", selector, currentVersion)
+		}
+
+		return currentVersion - uint32(offset), nil
+	}
+
+	version, err := strconv.Atoi(selector)
+	if err != nil {
+		return 0, err
+	}
+
+	if version < 1 || version > int(currentVersion) {
+		return 0, fmt.Errorf("version %d does not exist, the latest version is %d", version, currentVersion)
+	}
+
+	return uint32(version), nil
+}
+
 func resourcePolicyAssignmentImport(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
 	assignmentId := d.Id()
 	validationMessage := "policy assignment ids should be of the form: policies/$policyId/assignments/$policyGroupName"
@@ -186,3 +311,49 @@ func resourcePolicyAssignmentImport(_ context.Context, d *schema.ResourceData, _
 
 	return []*schema.ResourceData{d}, nil
 }
+
+// checkPolicyAssignmentsBeforeDelete enforces prevent_destroy_with_assignments and force_destroy for a policy or policy group.
+// With force_destroy, the assignments are deleted. Otherwise the assignments block the delete when prevent_destroy_with_assignments is set.
+func checkPolicyAssignmentsBeforeDelete(ctx context.Context, d *schema.ResourceData, rode *rodeClient, request *v1alpha1.ListPolicyAssignmentsRequest, kind string) diag.Diagnostics {
+	forceDestroy := d.Get("force_destroy").(bool)
+	if !forceDestroy && !d.Get("prevent_destroy_with_assignments").(bool) {
+		return nil
+	}
+
+	assignments, err := listPolicyAssignments(ctx, rode, request)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if forceDestroy {
+		for _, assignment := range assignments {
+			log.Printf("[DEBUG] Calling DeletePolicyAssignment RPC for %s before deleting %s %s\n", assignment.Id, kind, d.Id())
+			_, err := rode.DeletePolicyAssignment(ctx, &v1alpha1.DeletePolicyAssignmentRequest{Id: assignment.Id})
+			if err != nil && status.Code(err) != codes.NotFound {
+				return diag.FromErr(err)
+			}
+		}
+
+		return nil
+	}
+
+	if len(assignments) == 0 {
+		return nil
+	}
+
+	var blocking []string
+	for _, assignment := range assignments {
+		blocking = append(blocking, fmt.Sprintf("  - %s (policy version %s, policy group %s)", assignment.Id, assignment.PolicyVersionId, assignment.PolicyGroup))
+	}
+
+	return diag.Diagnostics{
+		{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("The %s still has policy assignments", kind),
+			Detail: fmt.Sprintf(
+				"prevent_destroy_with_assignments is set, and %s %s is used by %d policy assignments:\n%s\n\nDelete these assignments first, or set force_destroy to delete them along with the %s.",
+				kind, d.Id(), len(assignments), strings.Join(blocking, "\n"), kind,
+			),
+		},
+	}
+}
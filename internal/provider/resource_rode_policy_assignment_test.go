@@ -115,6 +115,64 @@ func TestAccPolicyAssignment_update(t *testing.T) {
 	})
 }
 
+func TestAccPolicyAssignment_version_selector(t *testing.T) {
+	resourceName := "rode_policy_assignment.test"
+	policy := &v1alpha1.Policy{
+		Name: fmt.Sprintf("tf-acc-%s", fake.LetterN(10)),
+		Policy: &v1alpha1.PolicyEntity{
+			RegoContent: minimalPolicy,
+		},
+	}
+	policyGroup := &v1alpha1.PolicyGroup{
+		Name: fmt.Sprintf("tf-acc-%s", strings.ToLower(fake.LetterN(10))),
+	}
+
+	updatedPolicy := proto.Clone(policy).(*v1alpha1.Policy)
+	updatedPolicy.Policy.RegoContent = updatedMinimalPolicy
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProvidersFactory,
+		CheckDestroy:      testAccPolicyAssignmentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPolicyAssignmentVersionSelectorConfig(policy, policyGroup, "latest"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "policy_version_id", "rode_policy.test", "policy_version_id"),
+					testAccCheckPolicyAssignmentExists(resourceName, policyGroup.Name),
+				),
+			},
+			{
+				Config: testAccPolicyAssignmentVersionSelectorConfig(updatedPolicy, policyGroup, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("rode_policy.test", "current_version", "2"),
+					testAccCheckPolicyAssignmentVersion(resourceName, 1),
+					testAccCheckPolicyAssignmentExists(resourceName, policyGroup.Name),
+				),
+			},
+			{
+				Config: testAccPolicyAssignmentVersionSelectorConfig(updatedPolicy, policyGroup, "latest"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "policy_version_id", "rode_policy.test", "policy_version_id"),
+					testAccCheckPolicyAssignmentVersion(resourceName, 2),
+				),
+			},
+			{
+				Config: testAccPolicyAssignmentVersionSelectorConfig(updatedPolicy, policyGroup, "latest-1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPolicyAssignmentVersion(resourceName, 1),
+				),
+			},
+			{
+				Config:      testAccPolicyAssignmentVersionSelectorConfig(updatedPolicy, policyGroup, "latest-2"),
+				ExpectError: regexp.MustCompile("latest-2 is before the first version"),
+			},
+		},
+	})
+}
+
 func TestAccPolicyAssignment_invalid_policy_group(t *testing.T) {
 	policyVersionId := fmt.Sprintf("%s.%d", fake.UUID(), fake.Number(1, 5))
 	policyGroupName := fmt.Sprintf("tf-acc-%s-$!@", strings.ToUpper(fake.LetterN(10)))
@@ -178,6 +236,33 @@ resource "rode_policy_assignment" "test" {
 		policy.Policy.RegoContent)
 }
 
+func testAccPolicyAssignmentVersionSelectorConfig(policy *v1alpha1.Policy, policyGroup *v1alpha1.PolicyGroup, versionSelector string) string {
+	return fmt.Sprintf(`
+resource "rode_policy_group" "test" {
+	name 		= "%s"
+	description = "%s"
+}
+
+resource "rode_policy" "test" {
+	name  		 = "%s"
+	rego_content = <<EOF
+%s
+EOF
+}
+
+resource "rode_policy_assignment" "test" {
+	policy_id        = rode_policy.test.id
+	version_selector = "%s"
+	policy_group     = rode_policy_group.test.name
+}
+`,
+		policyGroup.Name,
+		policyGroup.Description,
+		policy.Name,
+		policy.Policy.RegoContent,
+		versionSelector)
+}
+
 func testAccPolicyAssignmentConfig(policyVersionId, policyGroup string) string {
 	return fmt.Sprintf(`
 resource "rode_policy_assignment" "test" {
@@ -214,6 +299,26 @@ func testAccCheckPolicyAssignmentExists(resourceName, policyGroupName string) re
 	}
 }
 
+func testAccCheckPolicyAssignmentVersion(resourceName string, expectedVersion int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("policy assignment not found in state: %s", resourceName)
+		}
+
+		policyVersion, err := parsePolicyVersionId(rs.Primary.Attributes["policy_version_id"])
+		if err != nil {
+			return err
+		}
+
+		if policyVersion.version != expectedVersion {
+			return fmt.Errorf("expected assignment to policy version %d, but was %d", expectedVersion, policyVersion.version)
+		}
+
+		return nil
+	}
+}
+
 func testAccPolicyAssignmentDestroy(s *terraform.State) error {
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "rode_policy_assignment" {
@@ -0,0 +1,359 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/rode/rode/proto/v1alpha1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// policyBundleFile is a policy loaded from one of the files in a bundle
+type policyBundleFile struct {
+	path        string
+	regoContent string
+}
+
+func resourcePolicyBundle() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages a Rode policy for each `.rego` file in a directory. Policies are named after their Rego package, so a file declaring `package security.cve` becomes the `security.cve` policy. Files ending in `_test.rego` are skipped, and policies are deleted when their files are removed.",
+		CreateContext: resourcePolicyBundleCreate,
+		ReadContext:   resourcePolicyBundleRead,
+		UpdateContext: resourcePolicyBundleUpdate,
+		DeleteContext: resourcePolicyBundleDelete,
+		Timeouts:      defaultResourceTimeouts(),
+		CustomizeDiff: resourcePolicyBundleCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"source": {
+				Description: "A directory containing `.rego` files, or a glob pattern that matches them, e.g., `policies/*.rego`",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"description": {
+				Description: "A brief summary used for each policy in the bundle",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"message": {
+				Description: "A summary of changes used for each policy version created by the bundle",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"files": {
+				Description: "The file each policy was loaded from, keyed by policy name",
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"rego_content": {
+				Description: "The Rego code for each policy, keyed by policy name",
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"policy_ids": {
+				Description: "Policy ids, keyed by policy name",
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"policy_version_ids": {
+				Description: "The id of the current version of each policy, keyed by policy name",
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourcePolicyBundleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rode := meta.(*rodeClient)
+	if err := rode.init(ctx); err != nil {
+		return diag.FromErr(err)
+	}
+
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(id)
+
+	if err := syncPolicyBundle(ctx, d, rode); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourcePolicyBundleRead(ctx, d, meta)
+}
+
+func resourcePolicyBundleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rode := meta.(*rodeClient)
+	if err := rode.init(ctx); err != nil {
+		return diag.FromErr(err)
+	}
+
+	policyIds := map[string]interface{}{}
+	regoContent := map[string]interface{}{}
+	policyVersionIds := map[string]interface{}{}
+	for name, id := range d.Get("policy_ids").(map[string]interface{}) {
+		log.Printf("[DEBUG] Calling GetPolicy RPC for policy %s\n", name)
+		policy, err := rode.GetPolicy(ctx, &v1alpha1.GetPolicyRequest{Id: id.(string)})
+		if status.Code(err) == codes.NotFound || (err == nil && policy.Deleted) {
+			log.Printf("[WARN] Policy %s (%s) no longer exists, removing it from the bundle\n", name, id)
+			continue
+		}
+
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		policyIds[name] = policy.Id
+		regoContent[name] = policy.Policy.RegoContent
+		policyVersionIds[name] = policy.Policy.Id
+	}
+
+	d.Set("policy_ids", policyIds)
+	d.Set("rego_content", regoContent)
+	d.Set("policy_version_ids", policyVersionIds)
+
+	return nil
+}
+
+func resourcePolicyBundleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rode := meta.(*rodeClient)
+	if err := rode.init(ctx); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := syncPolicyBundle(ctx, d, rode); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourcePolicyBundleRead(ctx, d, meta)
+}
+
+func resourcePolicyBundleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rode := meta.(*rodeClient)
+	if err := rode.init(ctx); err != nil {
+		return diag.FromErr(err)
+	}
+
+	for name, id := range d.Get("policy_ids").(map[string]interface{}) {
+		log.Printf("[DEBUG] Calling DeletePolicy RPC for policy %s\n", name)
+		_, err := rode.DeletePolicy(ctx, &v1alpha1.DeletePolicyRequest{Id: id.(string)})
+		if err != nil && status.Code(err) != codes.NotFound {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+// syncPolicyBundle creates, updates, and deletes policies so that Rode matches the planned rego_content.
+// policy_ids is set even when an RPC fails, so that policies created before the error are tracked in state.
+func syncPolicyBundle(ctx context.Context, d *schema.ResourceData, rode *rodeClient) error {
+	oldIds, _ := d.GetChange("policy_ids")
+	oldContent, _ := d.GetChange("rego_content")
+	regoContent := d.Get("rego_content").(map[string]interface{})
+	descriptionChanged := d.HasChange("description")
+
+	policyIds := map[string]interface{}{}
+	for name, id := range oldIds.(map[string]interface{}) {
+		policyIds[name] = id
+	}
+	defer d.Set("policy_ids", policyIds)
+
+	var names []string
+	for name := range regoContent {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		policy := &v1alpha1.Policy{
+			Name:        name,
+			Description: d.Get("description").(string),
+			Policy: &v1alpha1.PolicyEntity{
+				Message:     d.Get("message").(string),
+				RegoContent: regoContent[name].(string),
+			},
+		}
+
+		id, ok := policyIds[name]
+		if !ok {
+			log.Printf("[DEBUG] Calling CreatePolicy RPC with: %v\n", policy)
+			response, err := rode.CreatePolicy(ctx, policy)
+			if err != nil {
+				return fmt.Errorf("error creating policy %s: %s", name, err)
+			}
+			log.Printf("[DEBUG] Successfully created policy: %v\n", response)
+
+			policyIds[name] = response.Id
+			continue
+		}
+
+		if oldContent.(map[string]interface{})[name] == regoContent[name] && !descriptionChanged {
+			continue
+		}
+
+		policy.Id = id.(string)
+		log.Printf("[DEBUG] Calling UpdatePolicy RPC with: %v\n", policy)
+		response, err := rode.UpdatePolicy(ctx, &v1alpha1.UpdatePolicyRequest{Policy: policy})
+		if err != nil {
+			return fmt.Errorf("error updating policy %s: %s", name, err)
+		}
+		log.Printf("[DEBUG] Successfully updated policy: %v\n", response)
+	}
+
+	for name, id := range policyIds {
+		if _, ok := regoContent[name]; ok {
+			continue
+		}
+
+		log.Printf("[DEBUG] Calling DeletePolicy RPC for policy %s, its file was removed from the bundle\n", name)
+		_, err := rode.DeletePolicy(ctx, &v1alpha1.DeletePolicyRequest{Id: id.(string)})
+		if err != nil && status.Code(err) != codes.NotFound {
+			return fmt.Errorf("error deleting policy %s: %s", name, err)
+		}
+		delete(policyIds, name)
+	}
+
+	return nil
+}
+
+// resourcePolicyBundleCustomizeDiff reads the bundle's files when planning, so that file changes show up as changes to rego_content
+func resourcePolicyBundleCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if !diff.NewValueKnown("source") {
+		for _, key := range []string{"files", "rego_content", "policy_ids", "policy_version_ids"} {
+			if err := diff.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	policies, err := loadPolicyBundle(diff.Get("source").(string))
+	if err != nil {
+		return cty.GetAttrPath("source").NewError(err)
+	}
+
+	currentContent := diff.Get("rego_content").(map[string]interface{})
+	currentIds := diff.Get("policy_ids").(map[string]interface{})
+	files := map[string]interface{}{}
+	regoContent := map[string]interface{}{}
+	contentChanged := len(policies) != len(currentContent)
+	policiesChanged := len(policies) != len(currentIds)
+
+	for name, policy := range policies {
+		files[name] = policy.path
+		regoContent[name] = policy.regoContent
+
+		if current, ok := currentContent[name].(string); ok && regoEquivalent(current, policy.regoContent) {
+			regoContent[name] = current
+		} else {
+			contentChanged = true
+		}
+
+		if _, ok := currentIds[name]; !ok {
+			policiesChanged = true
+		}
+	}
+
+	if err := diff.SetNew("files", files); err != nil {
+		return err
+	}
+
+	if !contentChanged {
+		return nil
+	}
+
+	if err := diff.SetNew("rego_content", regoContent); err != nil {
+		return err
+	}
+
+	if policiesChanged {
+		if err := diff.SetNewComputed("policy_ids"); err != nil {
+			return err
+		}
+	}
+
+	return diff.SetNewComputed("policy_version_ids")
+}
+
+// loadPolicyBundle reads each policy matched by source, keyed by the name of its Rego package
+func loadPolicyBundle(source string) (map[string]*policyBundleFile, error) {
+	pattern := source
+	if info, err := os.Stat(source); err == nil && info.IsDir() {
+		pattern = filepath.Join(source, "*.rego")
+	}
+
+	paths, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	policies := map[string]*policyBundleFile{}
+	for _, path := range paths {
+		if filepath.Ext(path) != ".rego" || strings.HasSuffix(path, "_test.rego") {
+			continue
+		}
+
+		regoContent, err := readRegoFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		name, err := regoPackageName(regoContent)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", path, err)
+		}
+
+		if existing, ok := policies[name]; ok {
+			return nil, fmt.Errorf("%s and %s both declare package %s, but each policy in a bundle must have a unique package", existing.path, path, name)
+		}
+
+		policies[name] = &policyBundleFile{
+			path:        path,
+			regoContent: regoContent,
+		}
+	}
+
+	if len(policies) == 0 {
+		return nil, fmt.Errorf("no .rego files found matching %s", pattern)
+	}
+
+	return policies, nil
+}
@@ -0,0 +1,113 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/rode/rode/proto/v1alpha1"
+)
+
+func TestAccPolicyBundle_basic(t *testing.T) {
+	resourceName := "rode_policy_bundle.test"
+	source := t.TempDir()
+	prefix := strings.ToLower(fmt.Sprintf("tf_acc_%s", fake.LetterN(10)))
+	first := prefix + "_first"
+	second := prefix + "_second"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProvidersFactory,
+		CheckDestroy:      testAccPolicyBundleDestroy,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					testAccWriteRegoFile(t, filepath.Join(source, "first.rego"), testAccBundledPolicy(first))()
+					testAccWriteRegoFile(t, filepath.Join(source, "second.rego"), testAccBundledPolicy(second))()
+				},
+				Config: testAccPolicyBundleConfig(source),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "policy_ids.%", "2"),
+					resource.TestCheckResourceAttrSet(resourceName, "policy_ids."+first),
+					resource.TestCheckResourceAttrSet(resourceName, "policy_version_ids."+second),
+					resource.TestCheckResourceAttr(resourceName, "files."+first, filepath.Join(source, "first.rego")),
+				),
+			},
+			{
+				PreConfig: func() {
+					if err := os.Remove(filepath.Join(source, "second.rego")); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccPolicyBundleConfig(source),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "policy_ids.%", "1"),
+					resource.TestCheckResourceAttrSet(resourceName, "policy_ids."+first),
+					resource.TestCheckNoResourceAttr(resourceName, "policy_ids."+second),
+				),
+			},
+		},
+	})
+}
+
+func testAccBundledPolicy(packageName string) string {
+	return strings.Replace(minimalPolicy, "package tf_acceptance", "package "+packageName, 1)
+}
+
+func testAccPolicyBundleConfig(source string) string {
+	return fmt.Sprintf(`
+resource "rode_policy_bundle" "test" {
+	source  = "%s"
+	message = "Terraform"
+}
+`, source)
+}
+
+func testAccPolicyBundleDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "rode_policy_bundle" {
+			continue
+		}
+
+		rodeClient := testAccProvider.Meta().(*rodeClient)
+		for key, id := range rs.Primary.Attributes {
+			if !strings.HasPrefix(key, "policy_ids.") || key == "policy_ids.%" {
+				continue
+			}
+
+			policy, err := rodeClient.GetPolicy(context.Background(), &v1alpha1.GetPolicyRequest{
+				Id: id,
+			})
+			if err != nil {
+				return err
+			}
+
+			if policy != nil && !policy.Deleted {
+				return fmt.Errorf("policy '%s' still exists", id)
+			}
+		}
+	}
+
+	return nil
+}
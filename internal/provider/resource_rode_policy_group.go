@@ -20,10 +20,13 @@ import (
 	"log"
 	"regexp"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/rode/rode/proto/v1alpha1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var (
@@ -34,11 +37,13 @@ var (
 
 func resourcePolicyGroup() *schema.Resource {
 	return &schema.Resource{
-		Description:   "A policy group is a collection of policies that Rode evaluates against a resource.",
+		Description:   "A policy group is a collection of policies that Rode evaluates against a resource. Rode soft-deletes policy groups when they are destroyed, and a deleted policy group can't be restored or recreated with the same name, so choose a new name when replacing one.",
 		CreateContext: resourcePolicyGroupCreate,
 		ReadContext:   resourcePolicyGroupRead,
 		UpdateContext: resourcePolicyGroupUpdate,
 		DeleteContext: resourcePolicyGroupDelete,
+		Timeouts:      defaultResourceTimeouts(),
+		CustomizeDiff: resourcePolicyGroupCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: resourcePolicyGroupImport,
 		},
@@ -70,13 +75,31 @@ func resourcePolicyGroup() *schema.Resource {
 				Computed:    true,
 				Type:        schema.TypeBool,
 			},
+			"keep_deleted": {
+				Description: "Allow the policy group to stay in state after it's soft-deleted outside of Terraform. Rode doesn't allow a deleted policy group's name to be reused, so it can't be recreated, and planning fails unless this is set or the policy group is renamed. The `deleted` attribute shows whether it has been deleted.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"prevent_destroy_with_assignments": {
+				Description: "Refuse to delete the policy group while policy assignments still reference it. Like `force_destroy`, this must be applied to the state before it takes effect on destroy.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"force_destroy": {
+				Description: "Delete any policy assignments that reference the policy group before deleting it. Must be applied to the state before it takes effect on destroy.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
 		},
 	}
 }
 
 func resourcePolicyGroupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	rode := meta.(*rodeClient)
-	if err := rode.init(); err != nil {
+	if err := rode.init(ctx); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -87,23 +110,34 @@ func resourcePolicyGroupCreate(ctx context.Context, d *schema.ResourceData, meta
 	log.Printf("[DEBUG] Calling CreatePolicyGroup RPC with: %v\n", policyGroup)
 	response, err := rode.CreatePolicyGroup(ctx, policyGroup)
 	if err != nil {
+		if status.Code(err) == codes.AlreadyExists {
+			return policyGroupConflictDiagnostics(ctx, rode, policyGroup.Name, err)
+		}
+
 		return diag.FromErr(err)
 	}
 	log.Printf("[DEBUG] Successfully created policy group: %v\n", response)
 	d.SetId(response.Name)
 
-	return resourcePolicyGroupRead(ctx, d, meta)
+	return resourcePolicyGroupRead(withReadAfterWrite(ctx), d, meta)
 }
 
 func resourcePolicyGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	rode := meta.(*rodeClient)
-	if err := rode.init(); err != nil {
+	if err := rode.init(ctx); err != nil {
 		return diag.FromErr(err)
 	}
 
 	log.Println("[DEBUG] Calling GetPolicyGroup RPC")
 	policyGroup, err := rode.GetPolicyGroup(ctx, &v1alpha1.GetPolicyGroupRequest{Name: d.Id()})
 	if err != nil {
+		// a new policy group may not be readable yet if Rode's index hasn't refreshed, so it's an error rather than a deletion
+		if status.Code(err) == codes.NotFound && !d.IsNewResource() {
+			log.Println("[DEBUG] Policy group appears to have been deleted")
+			d.SetId("")
+			return nil
+		}
+
 		return diag.FromErr(err)
 	}
 
@@ -118,7 +152,7 @@ func resourcePolicyGroupRead(ctx context.Context, d *schema.ResourceData, meta i
 
 func resourcePolicyGroupUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	rode := meta.(*rodeClient)
-	if err := rode.init(); err != nil {
+	if err := rode.init(ctx); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -139,22 +173,73 @@ func resourcePolicyGroupUpdate(ctx context.Context, d *schema.ResourceData, meta
 
 func resourcePolicyGroupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	rode := meta.(*rodeClient)
-	if err := rode.init(); err != nil {
+	if err := rode.init(ctx); err != nil {
 		return diag.FromErr(err)
 	}
 
+	if diags := checkPolicyAssignmentsBeforeDelete(ctx, d, rode, &v1alpha1.ListPolicyAssignmentsRequest{PolicyGroup: d.Id()}, "policy group"); diags.HasError() {
+		return diags
+	}
+
 	log.Println("[DEBUG] Calling DeletePolicyGroup RPC")
 	_, err := rode.DeletePolicyGroup(ctx, &v1alpha1.DeletePolicyGroupRequest{Name: d.Id()})
 
 	return diag.FromErr(err)
 }
 
+// resourcePolicyGroupCustomizeDiff fails the plan when the policy group was deleted outside of Terraform,
+// since Rode doesn't allow the policy group to be recreated with the same name
+func resourcePolicyGroupCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if diff.Id() == "" || diff.HasChange("name") || !diff.Get("deleted").(bool) || diff.Get("keep_deleted").(bool) {
+		return nil
+	}
+
+	return fmt.Errorf(
+		"policy group %s was deleted outside of Terraform. Rode keeps deleted policy groups and doesn't allow them to be recreated or restored. "+
+			"Set keep_deleted to keep the deleted policy group in state, choose a different name, or remove it from state with `terraform state rm`",
+		diff.Id(),
+	)
+}
+
+// policyGroupConflictDiagnostics explains why CreatePolicyGroup failed with AlreadyExists.
+// Rode keeps deleted policy groups, and their names can't be reused or restored.
+func policyGroupConflictDiagnostics(ctx context.Context, rode *rodeClient, name string, err error) diag.Diagnostics {
+	log.Println("[DEBUG] Calling GetPolicyGroup RPC")
+	existing, getErr := rode.GetPolicyGroup(ctx, &v1alpha1.GetPolicyGroupRequest{Name: name})
+	if getErr != nil {
+		return diag.FromErr(err)
+	}
+
+	if !existing.Deleted {
+		return diag.Diagnostics{
+			{
+				Severity:      diag.Error,
+				Summary:       "Policy group already exists",
+				Detail:        fmt.Sprintf("A policy group named %s already exists in Rode. To manage it with Terraform, import it with `terraform import`.", name),
+				AttributePath: cty.GetAttrPath("name"),
+			},
+		}
+	}
+
+	return diag.Diagnostics{
+		{
+			Severity:      diag.Error,
+			Summary:       "Policy group name belongs to a deleted policy group",
+			Detail:        fmt.Sprintf("The policy group %s was deleted, but Rode keeps deleted policy groups and doesn't allow them to be recreated or restored. Choose a different name for the policy group.", name),
+			AttributePath: cty.GetAttrPath("name"),
+		},
+	}
+}
+
 func resourcePolicyGroupImport(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
 	name := d.Id()
 
 	if !policyNameRegexp.MatchString(name) {
 		return nil, fmt.Errorf("%s does not match naming restrictions: %s", name, policyNameMessage)
 	}
+	d.Set("keep_deleted", false)
+	d.Set("prevent_destroy_with_assignments", false)
+	d.Set("force_destroy", false)
 
 	return []*schema.ResourceData{d}, nil
 }
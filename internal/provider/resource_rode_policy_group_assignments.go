@@ -0,0 +1,239 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/rode/rode/proto/v1alpha1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func resourcePolicyGroupAssignments() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Authoritatively manages the policy assignments of a policy group. Assignments in the group that aren't declared are deleted, so this resource shouldn't be used alongside `rode_policy_assignment` for the same policy group. Destroying this resource deletes all of the group's assignments.",
+		CreateContext: resourcePolicyGroupAssignmentsCreate,
+		ReadContext:   resourcePolicyGroupAssignmentsRead,
+		UpdateContext: resourcePolicyGroupAssignmentsUpdate,
+		DeleteContext: resourcePolicyGroupAssignmentsDelete,
+		Timeouts:      defaultResourceTimeouts(),
+		Importer: &schema.ResourceImporter{
+			StateContext: resourcePolicyGroupAssignmentsImport,
+		},
+		CustomizeDiff: resourcePolicyGroupAssignmentsCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"policy_group": {
+				Description:      "Name of the policy group",
+				Required:         true,
+				ForceNew:         true,
+				Type:             schema.TypeString,
+				ValidateDiagFunc: policyGroupNameValidateDiagFunc,
+			},
+			"policy_version_ids": {
+				Description: "The policy versions assigned to the policy group. A policy group can only be assigned one version of each policy.",
+				Required:    true,
+				Type:        schema.TypeSet,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: policyVersionIdValidateDiagFunc,
+				},
+			},
+			"assignments": {
+				Description: "The policy assignments in the policy group",
+				Computed:    true,
+				Type:        schema.TypeList,
+				Elem:        policyAssignmentElem(),
+			},
+		},
+	}
+}
+
+func resourcePolicyGroupAssignmentsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rode := meta.(*rodeClient)
+	if err := rode.init(ctx); err != nil {
+		return diag.FromErr(err)
+	}
+
+	policyGroup := d.Get("policy_group").(string)
+	if err := reconcilePolicyGroupAssignments(ctx, rode, policyGroup, d.Get("policy_version_ids").(*schema.Set)); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(policyGroup)
+
+	return resourcePolicyGroupAssignmentsRead(ctx, d, meta)
+}
+
+func resourcePolicyGroupAssignmentsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rode := meta.(*rodeClient)
+	if err := rode.init(ctx); err != nil {
+		return diag.FromErr(err)
+	}
+
+	assignments, err := listPolicyAssignments(ctx, rode, &v1alpha1.ListPolicyAssignmentsRequest{PolicyGroup: d.Id()})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var policyVersionIds []interface{}
+	var flattenedAssignments []interface{}
+	for _, assignment := range assignments {
+		policyVersionIds = append(policyVersionIds, assignment.PolicyVersionId)
+		flattenedAssignments = append(flattenedAssignments, flattenPolicyAssignment(assignment))
+	}
+
+	d.Set("policy_group", d.Id())
+	d.Set("policy_version_ids", schema.NewSet(schema.HashString, policyVersionIds))
+	d.Set("assignments", flattenedAssignments)
+
+	return nil
+}
+
+func resourcePolicyGroupAssignmentsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rode := meta.(*rodeClient)
+	if err := rode.init(ctx); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := reconcilePolicyGroupAssignments(ctx, rode, d.Id(), d.Get("policy_version_ids").(*schema.Set)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourcePolicyGroupAssignmentsRead(ctx, d, meta)
+}
+
+func resourcePolicyGroupAssignmentsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rode := meta.(*rodeClient)
+	if err := rode.init(ctx); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(reconcilePolicyGroupAssignments(ctx, rode, d.Id(), schema.NewSet(schema.HashString, nil)))
+}
+
+func resourcePolicyGroupAssignmentsImport(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	name := d.Id()
+	if !policyNameRegexp.MatchString(name) {
+		return nil, fmt.Errorf("%s does not match naming restrictions: %s", name, policyNameMessage)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourcePolicyGroupAssignmentsCustomizeDiff rejects multiple versions of the same policy,
+// since Rode identifies an assignment by its policy and policy group
+func resourcePolicyGroupAssignmentsCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if !diff.HasChange("policy_version_ids") {
+		return nil
+	}
+
+	if err := diff.SetNewComputed("assignments"); err != nil {
+		return err
+	}
+
+	if !diff.NewValueKnown("policy_version_ids") {
+		return nil
+	}
+
+	policyVersionIds := map[string]string{}
+	for _, v := range diff.Get("policy_version_ids").(*schema.Set).List() {
+		policyVersionId := v.(string)
+		components, err := parsePolicyVersionId(policyVersionId)
+		if err != nil {
+			// unknown values and invalid ids are left to the attribute validation
+			continue
+		}
+
+		if existing, ok := policyVersionIds[components.policyId]; ok {
+			return cty.GetAttrPath("policy_version_ids").NewErrorf("%s and %s are versions of the same policy, but a policy group can only be assigned one version of a policy", existing, policyVersionId)
+		}
+		policyVersionIds[components.policyId] = policyVersionId
+	}
+
+	return nil
+}
+
+// reconcilePolicyGroupAssignments creates, updates, and deletes assignments so that the policy group is assigned exactly policyVersionIds
+func reconcilePolicyGroupAssignments(ctx context.Context, rode *rodeClient, policyGroup string, policyVersionIds *schema.Set) error {
+	current, err := listPolicyAssignments(ctx, rode, &v1alpha1.ListPolicyAssignmentsRequest{PolicyGroup: policyGroup})
+	if err != nil {
+		return err
+	}
+
+	currentByPolicyId := map[string]*v1alpha1.PolicyAssignment{}
+	for _, assignment := range current {
+		components, err := parsePolicyVersionId(assignment.PolicyVersionId)
+		if err != nil {
+			return err
+		}
+		currentByPolicyId[components.policyId] = assignment
+	}
+
+	desiredPolicyIds := map[string]bool{}
+	for _, v := range policyVersionIds.List() {
+		policyVersionId := v.(string)
+		components, err := parsePolicyVersionId(policyVersionId)
+		if err != nil {
+			return err
+		}
+		desiredPolicyIds[components.policyId] = true
+
+		existing, ok := currentByPolicyId[components.policyId]
+		if !ok {
+			assignment := &v1alpha1.PolicyAssignment{
+				PolicyVersionId: policyVersionId,
+				PolicyGroup:     policyGroup,
+			}
+			log.Printf("[DEBUG] Calling CreatePolicyAssignment RPC with: %v\n", assignment)
+			if _, err := rode.CreatePolicyAssignment(ctx, assignment); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if existing.PolicyVersionId == policyVersionId {
+			continue
+		}
+
+		assignment := &v1alpha1.PolicyAssignment{
+			Id:              existing.Id,
+			PolicyVersionId: policyVersionId,
+			PolicyGroup:     policyGroup,
+		}
+		log.Printf("[DEBUG] Calling UpdatePolicyAssignment RPC with: %v\n", assignment)
+		if _, err := rode.UpdatePolicyAssignment(ctx, assignment); err != nil {
+			return err
+		}
+	}
+
+	for policyId, assignment := range currentByPolicyId {
+		if desiredPolicyIds[policyId] {
+			continue
+		}
+
+		log.Printf("[DEBUG] Calling DeletePolicyAssignment RPC for %s\n", assignment.Id)
+		_, err := rode.DeletePolicyAssignment(ctx, &v1alpha1.DeletePolicyAssignmentRequest{Id: assignment.Id})
+		if err != nil && status.Code(err) != codes.NotFound {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/rode/rode/proto/v1alpha1"
+)
+
+func TestAccPolicyGroupAssignments_basic(t *testing.T) {
+	resourceName := "rode_policy_group_assignments.test"
+	policyGroupName := fmt.Sprintf("tf-acc-%s", strings.ToLower(fake.LetterN(10)))
+	policyName := fmt.Sprintf("tf-acc-%s", fake.LetterN(10))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProvidersFactory,
+		CheckDestroy:      testAccPolicyGroupAssignmentsDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPolicyGroupAssignmentsConfig(policyGroupName, policyName, "rode_policy.first.policy_version_id"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "assignments.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair(resourceName, "policy_version_ids.*", "rode_policy.first", "policy_version_id"),
+					testAccPolicyAssignedOutOfBand("rode_policy.second", "rode_policy_group.test"),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccPolicyGroupAssignmentsConfig(policyGroupName, policyName, "rode_policy.first.policy_version_id"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "assignments.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "policy_version_ids.#", "1"),
+				),
+			},
+			{
+				Config: testAccPolicyGroupAssignmentsConfig(policyGroupName, policyName, "rode_policy.first.policy_version_id", "rode_policy.second.policy_version_id"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "assignments.#", "2"),
+					resource.TestCheckTypeSetElemAttrPair(resourceName, "policy_version_ids.*", "rode_policy.second", "policy_version_id"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccPolicyGroupAssignmentsConfig(policyGroupName, policyName string, policyVersionIds ...string) string {
+	return fmt.Sprintf(`
+resource "rode_policy_group" "test" {
+	name = "%[1]s"
+}
+
+resource "rode_policy" "first" {
+	name         = "%[2]s-first"
+	rego_content = <<EOF
+%[3]s
+EOF
+}
+
+resource "rode_policy" "second" {
+	name         = "%[2]s-second"
+	rego_content = <<EOF
+%[3]s
+EOF
+}
+
+resource "rode_policy_group_assignments" "test" {
+	policy_group       = rode_policy_group.test.name
+	policy_version_ids = [%[4]s]
+}
+`, policyGroupName, policyName, minimalPolicy, strings.Join(policyVersionIds, ", "))
+}
+
+func testAccPolicyGroupAssignmentsDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "rode_policy_group_assignments" {
+			continue
+		}
+
+		rodeClient := testAccProvider.Meta().(*rodeClient)
+		response, err := rodeClient.ListPolicyAssignments(context.Background(), &v1alpha1.ListPolicyAssignmentsRequest{
+			PolicyGroup: rs.Primary.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(response.PolicyAssignments) != 0 {
+			return fmt.Errorf("policy group %s still has %d policy assignments", rs.Primary.ID, len(response.PolicyAssignments))
+		}
+	}
+
+	return nil
+}
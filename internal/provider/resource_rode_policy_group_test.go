@@ -104,6 +104,119 @@ func TestAccPolicyGroup_update(t *testing.T) {
 	})
 }
 
+func TestAccPolicyGroup_disappears(t *testing.T) {
+	resourceName := "rode_policy_group.test"
+	policyGroup := &v1alpha1.PolicyGroup{
+		Name:        fmt.Sprintf("tf-acc-%s", strings.ToLower(fake.LetterN(10))),
+		Description: fake.LetterN(10),
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProvidersFactory,
+		CheckDestroy:      testAccCheckPolicyGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPolicyGroupConfig(policyGroup),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPolicyGroupExists(resourceName, policyGroup),
+					testAccCheckPolicyGroupDisappears(resourceName),
+				),
+				ExpectError: regexp.MustCompile("was deleted outside of Terraform"),
+			},
+		},
+	})
+}
+
+func TestAccPolicyGroup_recreate_deleted(t *testing.T) {
+	resourceName := "rode_policy_group.test"
+	policyGroup := &v1alpha1.PolicyGroup{
+		Name:        fmt.Sprintf("tf-acc-%s", strings.ToLower(fake.LetterN(10))),
+		Description: fake.LetterN(10),
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProvidersFactory,
+		CheckDestroy:      testAccCheckPolicyGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPolicyGroupConfig(policyGroup),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPolicyGroupExists(resourceName, policyGroup),
+					testAccCheckPolicyGroupDisappears(resourceName),
+				),
+				ExpectError: regexp.MustCompile("was deleted outside of Terraform"),
+			},
+			{
+				Config:      testAccPolicyGroupRecreateConfig(policyGroup),
+				ExpectError: regexp.MustCompile("Policy group name belongs to a deleted policy group"),
+			},
+		},
+	})
+}
+
+func TestAccPolicyGroup_keep_deleted(t *testing.T) {
+	resourceName := "rode_policy_group.test"
+	policyGroup := &v1alpha1.PolicyGroup{
+		Name:        fmt.Sprintf("tf-acc-%s", strings.ToLower(fake.LetterN(10))),
+		Description: fake.LetterN(10),
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProvidersFactory,
+		CheckDestroy:      testAccCheckPolicyGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPolicyGroupKeepDeletedConfig(policyGroup),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPolicyGroupExists(resourceName, policyGroup),
+					testAccCheckPolicyGroupDisappears(resourceName),
+				),
+			},
+			{
+				Config: testAccPolicyGroupKeepDeletedConfig(policyGroup),
+				Check:  resource.TestCheckResourceAttr(resourceName, "deleted", "true"),
+			},
+		},
+	})
+}
+
+func TestAccPolicyGroup_prevent_destroy_with_assignments(t *testing.T) {
+	policyName := fmt.Sprintf("tf-acc-%s", fake.LetterN(10))
+	policyGroupName := fmt.Sprintf("tf-acc-%s", strings.ToLower(fake.LetterN(10)))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProvidersFactory,
+		CheckDestroy:      testAccCheckPolicyGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPolicyGroupDestroyGuardConfig(policyGroupName, policyName, false),
+				Check:  testAccPolicyAssignedOutOfBand("rode_policy.test", "rode_policy_group.test"),
+			},
+			{
+				Config:      testAccPolicyGroupDestroyGuardConfig(policyGroupName, policyName, false),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile("The policy group still has policy assignments"),
+			},
+			{
+				Config: testAccPolicyGroupDestroyGuardConfig(policyGroupName, policyName, true),
+				Check:  resource.TestCheckResourceAttr("rode_policy_group.test", "force_destroy", "true"),
+			},
+		},
+	})
+}
+
 func TestAccPolicyGroup_validate_name(t *testing.T) {
 	policyGroup := &v1alpha1.PolicyGroup{
 		Name: fmt.Sprintf("tf-acc-%s!@#$", strings.ToUpper(fake.LetterN(10))),
@@ -132,6 +245,46 @@ resource "rode_policy_group" "test" {
 `, policyGroup.Name, policyGroup.Description)
 }
 
+// testAccPolicyGroupRecreateConfig moves the policy group to a new resource, so that Terraform tries to create it again
+func testAccPolicyGroupRecreateConfig(policyGroup *v1alpha1.PolicyGroup) string {
+	return fmt.Sprintf(`
+resource "rode_policy_group" "recreated" {
+  name        = "%s"
+  description = "%s"
+}
+`, policyGroup.Name, policyGroup.Description)
+}
+
+func testAccPolicyGroupKeepDeletedConfig(policyGroup *v1alpha1.PolicyGroup) string {
+	return fmt.Sprintf(`
+resource "rode_policy_group" "test" {
+  name         = "%s"
+  description  = "%s"
+  keep_deleted = true
+}
+`, policyGroup.Name, policyGroup.Description)
+}
+
+func testAccPolicyGroupDestroyGuardConfig(policyGroupName, policyName string, forceDestroy bool) string {
+	return fmt.Sprintf(`
+resource "rode_policy" "test" {
+  name         = "%s"
+  rego_content = <<EOF
+%s
+EOF
+}
+
+resource "rode_policy_group" "test" {
+  name                             = "%s"
+  prevent_destroy_with_assignments = true
+  force_destroy                    = %t
+
+  # destroy the policy group first, so the policy is kept when the policy group can't be deleted
+  depends_on = [rode_policy.test]
+}
+`, policyName, minimalPolicy, policyGroupName, forceDestroy)
+}
+
 func testAccCheckPolicyGroupExists(resourceName string, expected *v1alpha1.PolicyGroup) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[resourceName]
@@ -164,6 +317,22 @@ func testAccCheckPolicyGroupExists(resourceName string, expected *v1alpha1.Polic
 	}
 }
 
+func testAccCheckPolicyGroupDisappears(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("policy group not found in state: %s", resourceName)
+		}
+
+		rodeClient := testAccProvider.Meta().(*rodeClient)
+		_, err := rodeClient.DeletePolicyGroup(context.Background(), &v1alpha1.DeletePolicyGroupRequest{
+			Name: rs.Primary.ID,
+		})
+
+		return err
+	}
+}
+
 func testAccCheckPolicyGroupDestroy(s *terraform.State) error {
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "rode_policy_group" {
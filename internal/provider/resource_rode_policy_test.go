@@ -17,10 +17,14 @@ package provider
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
 	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/rode/rode/proto/v1alpha1"
 	"google.golang.org/protobuf/proto"
@@ -134,6 +138,285 @@ func TestAccPolicy_update(t *testing.T) {
 	})
 }
 
+func TestAccPolicy_disappears(t *testing.T) {
+	resourceName := "rode_policy.test"
+	policy := &v1alpha1.Policy{
+		Name: fmt.Sprintf("tf-acc-%s", fake.LetterN(10)),
+		Policy: &v1alpha1.PolicyEntity{
+			RegoContent: minimalPolicy,
+		},
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProvidersFactory,
+		CheckDestroy:      testAccPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPolicyConfig(policy),
+				Check: resource.ComposeTestCheckFunc(
+					testAccPolicyExists(resourceName, policy, 1),
+					testAccPolicyDisappears(resourceName),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccPolicy_prevent_destroy_with_assignments(t *testing.T) {
+	policyName := fmt.Sprintf("tf-acc-%s", fake.LetterN(10))
+	policyGroupName := fmt.Sprintf("tf-acc-%s", strings.ToLower(fake.LetterN(10)))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProvidersFactory,
+		CheckDestroy:      testAccPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPolicyDestroyGuardConfig(policyName, policyGroupName, false),
+				Check:  testAccPolicyAssignedOutOfBand("rode_policy.test", "rode_policy_group.test"),
+			},
+			{
+				Config:      testAccPolicyDestroyGuardConfig(policyName, policyGroupName, false),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile("The policy still has policy assignments"),
+			},
+			{
+				Config: testAccPolicyDestroyGuardConfig(policyName, policyGroupName, true),
+				Check:  resource.TestCheckResourceAttr("rode_policy.test", "force_destroy", "true"),
+			},
+		},
+	})
+}
+
+func TestAccPolicy_rego_file(t *testing.T) {
+	resourceName := "rode_policy.test"
+	regoFile := filepath.Join(t.TempDir(), "policy.rego")
+	policy := &v1alpha1.Policy{
+		Name: fmt.Sprintf("tf-acc-%s", fake.LetterN(10)),
+		Policy: &v1alpha1.PolicyEntity{
+			RegoContent: minimalPolicy,
+		},
+	}
+	updatedPolicy := proto.Clone(policy).(*v1alpha1.Policy)
+	updatedPolicy.Policy.RegoContent = updatedMinimalPolicy
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProvidersFactory,
+		CheckDestroy:      testAccPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: testAccWriteRegoFile(t, regoFile, minimalPolicy),
+				Config:    testAccPolicyRegoFileConfig(policy.Name, regoFile),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "rego_file", regoFile),
+					resource.TestCheckResourceAttr(resourceName, "source_hash", regoSourceHash(minimalPolicy)),
+					testAccPolicyExists(resourceName, policy, 1),
+				),
+			},
+			{
+				PreConfig: testAccWriteRegoFile(t, regoFile, updatedMinimalPolicy),
+				Config:    testAccPolicyRegoFileConfig(policy.Name, regoFile),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "source_hash", regoSourceHash(updatedMinimalPolicy)),
+					testAccPolicyExists(resourceName, updatedPolicy, 2),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPolicy_formatting_changes(t *testing.T) {
+	resourceName := "rode_policy.test"
+	policy := &v1alpha1.Policy{
+		Name: fmt.Sprintf("tf-acc-%s", fake.LetterN(10)),
+		Policy: &v1alpha1.PolicyEntity{
+			RegoContent: minimalPolicy,
+		},
+	}
+	reformattedPolicy := &v1alpha1.Policy{
+		Name: policy.Name,
+		Policy: &v1alpha1.PolicyEntity{
+			RegoContent: "# reformatted\n" + strings.ReplaceAll(minimalPolicy, "\t", "  "),
+		},
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProvidersFactory,
+		CheckDestroy:      testAccPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPolicyConfig(policy),
+				Check:  testAccPolicyExists(resourceName, policy, 1),
+			},
+			{
+				Config:   testAccPolicyConfig(reformattedPolicy),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestResourcePolicyDiff_formattingChanges(t *testing.T) {
+	tests := map[string]struct {
+		regoContent      string
+		expectNewVersion bool
+	}{
+		"unchanged": {
+			regoContent: minimalPolicy,
+		},
+		"whitespace": {
+			regoContent: strings.ReplaceAll(minimalPolicy, "\t", "  ") + "\n\n",
+		},
+		"comments": {
+			regoContent: "# reformatted\n" + strings.ReplaceAll(minimalPolicy, "pass {", "pass { # always passes"),
+		},
+		"logic": {
+			regoContent:      updatedMinimalPolicy,
+			expectNewVersion: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			policy := resourcePolicy()
+			state := testResourcePolicyState(t, policy, minimalPolicy)
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{
+				"name":         "policy",
+				"rego_content": tc.regoContent,
+			})
+
+			// policy validation is disabled so that a semantic change doesn't need a Rode client
+			diff, err := policy.SimpleDiff(context.Background(), state, config, &rodeClient{disablePolicyValidation: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !tc.expectNewVersion {
+				if diff != nil && !diff.Empty() {
+					t.Errorf("expected an empty diff, got %v", diff.Attributes)
+				}
+				return
+			}
+
+			if diff == nil || diff.Attributes["policy_version_id"] == nil || !diff.Attributes["policy_version_id"].NewComputed {
+				t.Errorf("expected a new policy version, got %v", diff)
+			}
+		})
+	}
+}
+
+// testResourcePolicyState returns the state of a policy that was created with regoContent
+func testResourcePolicyState(t *testing.T, policy *schema.Resource, regoContent string) *terraform.InstanceState {
+	d := schema.TestResourceDataRaw(t, policy.Schema, map[string]interface{}{
+		"name":         "policy",
+		"rego_content": regoContent,
+	})
+	d.SetId("policy-id")
+	d.Set("policy_version_id", "policy-id.1")
+	d.Set("current_version", 1)
+	d.Set("message", "")
+	d.Set("source_hash", regoSourceHash(regoContent))
+	d.Set("created", "2021-01-01T00:00:00Z")
+	d.Set("updated", "2021-01-01T00:00:00Z")
+	d.Set("deleted", false)
+
+	return d.State()
+}
+
+func TestAccPolicy_invalid_rego(t *testing.T) {
+	policy := &v1alpha1.Policy{
+		Name: fmt.Sprintf("tf-acc-%s", fake.LetterN(10)),
+		Policy: &v1alpha1.PolicyEntity{
+			RegoContent: "package tf_acc\n\npass {\n\tx := [1, 2\n}\n",
+		},
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProvidersFactory,
+		CheckDestroy:      testAccPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccPolicyConfig(policy),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`line 5, column 1: rego_parse_error`),
+			},
+		},
+	})
+}
+
+func TestAccPolicy_missing_rules(t *testing.T) {
+	policy := &v1alpha1.Policy{
+		Name: fmt.Sprintf("tf-acc-%s", fake.LetterN(10)),
+		Policy: &v1alpha1.PolicyEntity{
+			RegoContent: "package tf_acc\n\npass {\n\ttrue\n}\n",
+		},
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProvidersFactory,
+		CheckDestroy:      testAccPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccPolicyConfig(policy),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`policy must contain a "violations" rule`),
+			},
+		},
+	})
+}
+
+func TestAccPolicy_tests(t *testing.T) {
+	resourceName := "rode_policy.test"
+	policy := &v1alpha1.Policy{
+		Name: fmt.Sprintf("tf-acc-%s", fake.LetterN(10)),
+		Policy: &v1alpha1.PolicyEntity{
+			RegoContent: minimalPolicy,
+		},
+	}
+	passingTests := "package tf_acceptance\n\ntest_pass {\n\tpass with input as {}\n}"
+	failingTests := "package tf_acceptance\n\ntest_violations {\n\tcount(violations) == 2 with input as {}\n}"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProvidersFactory,
+		CheckDestroy:      testAccPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPolicyTestsConfig(policy, passingTests),
+				Check: resource.ComposeTestCheckFunc(
+					testAccPolicyExists(resourceName, policy, 1),
+					resource.TestCheckResourceAttr(resourceName, "test_content", passingTests+"\n"),
+				),
+			},
+			{
+				Config:      testAccPolicyTestsConfig(policy, failingTests),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`line 4, column 2: test_violations failed`),
+			},
+		},
+	})
+}
+
 func testAccPolicyConfig(policy *v1alpha1.Policy) string {
 	return fmt.Sprintf(`
 resource "rode_policy" "test" {
@@ -152,6 +435,84 @@ EOF
 	)
 }
 
+func testAccPolicyTestsConfig(policy *v1alpha1.Policy, tests string) string {
+	return fmt.Sprintf(`
+resource "rode_policy" "test" {
+	name         = "%s"
+	rego_content = <<EOF
+%s
+EOF
+	test_content = <<EOF
+%s
+EOF
+}
+`,
+		policy.Name,
+		policy.Policy.RegoContent,
+		tests,
+	)
+}
+
+func testAccPolicyRegoFileConfig(name, regoFile string) string {
+	return fmt.Sprintf(`
+resource "rode_policy" "test" {
+	name      = "%s"
+	rego_file = "%s"
+}
+`, name, regoFile)
+}
+
+func testAccWriteRegoFile(t *testing.T, path, regoContent string) func() {
+	return func() {
+		if err := os.WriteFile(path, []byte(regoContent), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func testAccPolicyDestroyGuardConfig(policyName, policyGroupName string, forceDestroy bool) string {
+	return fmt.Sprintf(`
+resource "rode_policy_group" "test" {
+	name = "%s"
+}
+
+resource "rode_policy" "test" {
+	name                             = "%s"
+	prevent_destroy_with_assignments = true
+	force_destroy                    = %t
+	rego_content                     = <<EOF
+%s
+EOF
+
+	# destroy the policy first, so the policy group is kept when the policy can't be deleted
+	depends_on = [rode_policy_group.test]
+}
+`, policyGroupName, policyName, forceDestroy, minimalPolicy)
+}
+
+// testAccPolicyAssignedOutOfBand assigns the policy to the policy group without Terraform knowing about the assignment
+func testAccPolicyAssignedOutOfBand(policyResourceName, policyGroupResourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		policy, ok := s.RootModule().Resources[policyResourceName]
+		if !ok {
+			return fmt.Errorf("policy not found in state: %s", policyResourceName)
+		}
+
+		policyGroup, ok := s.RootModule().Resources[policyGroupResourceName]
+		if !ok {
+			return fmt.Errorf("policy group not found in state: %s", policyGroupResourceName)
+		}
+
+		rodeClient := testAccProvider.Meta().(*rodeClient)
+		_, err := rodeClient.CreatePolicyAssignment(context.Background(), &v1alpha1.PolicyAssignment{
+			PolicyVersionId: policy.Primary.Attributes["policy_version_id"],
+			PolicyGroup:     policyGroup.Primary.ID,
+		})
+
+		return err
+	}
+}
+
 func testAccPolicyExists(resourceName string, expected *v1alpha1.Policy, expectedVersion uint32) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[resourceName]
@@ -191,6 +552,22 @@ func testAccPolicyExists(resourceName string, expected *v1alpha1.Policy, expecte
 	}
 }
 
+func testAccPolicyDisappears(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("policy not found in state: %s", resourceName)
+		}
+
+		rodeClient := testAccProvider.Meta().(*rodeClient)
+		_, err := rodeClient.DeletePolicy(context.Background(), &v1alpha1.DeletePolicyRequest{
+			Id: rs.Primary.ID,
+		})
+
+		return err
+	}
+}
+
 func testAccPolicyDestroy(s *terraform.State) error {
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "rode_policy" {
@@ -0,0 +1,381 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/rode/rode/proto/v1alpha1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const defaultEvaluationSourceName = "terraform"
+
+func resourceResourceEvaluation() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Evaluates a resource against a policy group with the EvaluateResource RPC when created. The evaluation is re-run when `resource_uri` or `policy_group` change, or when the policy versions assigned to the policy group change. Set `fail_on_violation` to use the evaluation as a gate for the rest of the apply. Destroying this resource only removes it from the Terraform state, since evaluations are kept as history.",
+		CreateContext: resourceResourceEvaluationCreate,
+		ReadContext:   resourceResourceEvaluationRead,
+		UpdateContext: resourceResourceEvaluationUpdate,
+		DeleteContext: resourceResourceEvaluationDelete,
+		Timeouts:      defaultResourceTimeouts(),
+		CustomizeDiff: resourceResourceEvaluationCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"resource_uri": {
+				Description: "The URI of the resource version to evaluate",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"policy_group": {
+				Description:      "Name of the policy group to evaluate the resource against",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: policyGroupNameValidateDiagFunc,
+			},
+			"source_name": {
+				Description: "Name of the system that requested the evaluation",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     defaultEvaluationSourceName,
+			},
+			"source_url": {
+				Description: "Link to the system that requested the evaluation, e.g., a CI job",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"fail_on_violation": {
+				Description: "Return an error if the resource fails the evaluation",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"policy_version_ids": {
+				Description: "The policy versions that were evaluated. A change to the policy group's assignments re-runs the evaluation.",
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"pass": resourceEvaluationPassSchema(),
+			"created": {
+				Description: "Evaluation timestamp",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"resource_version": {
+				Description: "The version of the resource that was evaluated",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"policy_evaluations": policyEvaluationsSchema(),
+		},
+	}
+}
+
+func resourceResourceEvaluationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rode := meta.(*rodeClient)
+	if err := rode.init(ctx); err != nil {
+		return diag.FromErr(err)
+	}
+
+	result, err := evaluateResource(ctx, rode, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(result.ResourceEvaluation.Id)
+
+	if err := setResourceEvaluationResult(d, result); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return failedResourceEvaluationDiagnostics(d)
+}
+
+func resourceResourceEvaluationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rode := meta.(*rodeClient)
+	if err := rode.init(ctx); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Println("[DEBUG] Calling GetResourceEvaluation RPC")
+	result, err := rode.GetResourceEvaluation(ctx, &v1alpha1.GetResourceEvaluationRequest{Id: d.Id()})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			log.Println("[DEBUG] Resource evaluation appears to have been deleted")
+			d.SetId("")
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	if err := setResourceEvaluationResult(d, result); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// resourceResourceEvaluationUpdate re-runs the evaluation when the policy group's assignments changed.
+// Every other argument except fail_on_violation forces a new resource.
+func resourceResourceEvaluationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if !d.HasChange("policy_version_ids") {
+		diags := resourceResourceEvaluationRead(ctx, d, meta)
+		if diags.HasError() || d.Id() == "" {
+			return diags
+		}
+
+		return failedResourceEvaluationDiagnostics(d)
+	}
+
+	rode := meta.(*rodeClient)
+	if err := rode.init(ctx); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] Re-evaluating %s, the assignments for policy group %s changed\n", d.Get("resource_uri"), d.Get("policy_group"))
+	result, err := evaluateResource(ctx, rode, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(result.ResourceEvaluation.Id)
+
+	if err := setResourceEvaluationResult(d, result); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return failedResourceEvaluationDiagnostics(d)
+}
+
+func resourceResourceEvaluationDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Removing resource evaluation %s from state\n", d.Id())
+
+	return nil
+}
+
+// resourceResourceEvaluationCustomizeDiff plans to re-run the evaluation when the versions assigned to the policy group
+// no longer match the ones that were evaluated
+func resourceResourceEvaluationCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() == "" || diff.HasChange("policy_group") {
+		return nil
+	}
+
+	rode := meta.(*rodeClient)
+	if err := rode.init(ctx); err != nil {
+		return err
+	}
+
+	assignments, err := listPolicyAssignments(ctx, rode, &v1alpha1.ListPolicyAssignmentsRequest{
+		PolicyGroup: diff.Get("policy_group").(string),
+	})
+	if err != nil {
+		return err
+	}
+
+	var assigned []interface{}
+	for _, assignment := range assignments {
+		assigned = append(assigned, assignment.PolicyVersionId)
+	}
+
+	// the set read from state hashes with the schema's default function rather than schema.HashString
+	evaluatedSet := diff.Get("policy_version_ids").(*schema.Set)
+	if schema.NewSet(evaluatedSet.F, assigned).Equal(evaluatedSet) {
+		return nil
+	}
+
+	// the results are unknown until the update re-runs the evaluation, since the assignments can change again before then
+	log.Printf("[DEBUG] Assignments for policy group %s changed since evaluation %s\n", diff.Get("policy_group"), diff.Id())
+	for _, key := range []string{"policy_version_ids", "pass", "created", "resource_version", "policy_evaluations"} {
+		if err := diff.SetNewComputed(key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func evaluateResource(ctx context.Context, rode *rodeClient, d *schema.ResourceData) (*v1alpha1.ResourceEvaluationResult, error) {
+	request := &v1alpha1.ResourceEvaluationRequest{
+		ResourceUri: d.Get("resource_uri").(string),
+		PolicyGroup: d.Get("policy_group").(string),
+		Source: &v1alpha1.ResourceEvaluationSource{
+			Name: d.Get("source_name").(string),
+			Url:  d.Get("source_url").(string),
+		},
+	}
+
+	log.Printf("[DEBUG] Calling EvaluateResource RPC with: %v\n", request)
+	result, err := rode.EvaluateResource(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("[DEBUG] Resource evaluation %s pass: %t\n", result.ResourceEvaluation.Id, result.ResourceEvaluation.Pass)
+
+	return result, nil
+}
+
+func setResourceEvaluationResult(d *schema.ResourceData, result *v1alpha1.ResourceEvaluationResult) error {
+	evaluation := result.ResourceEvaluation
+	d.Set("pass", evaluation.Pass)
+	d.Set("created", formatProtoTimestamp(evaluation.Created))
+	if evaluation.ResourceVersion != nil {
+		d.Set("resource_version", evaluation.ResourceVersion.Version)
+	}
+
+	var policyVersionIds []interface{}
+	for _, policyEvaluation := range result.PolicyEvaluations {
+		policyVersionIds = append(policyVersionIds, policyEvaluation.PolicyVersionId)
+	}
+	d.Set("policy_version_ids", schema.NewSet(schema.HashString, policyVersionIds))
+
+	return d.Set("policy_evaluations", flattenPolicyEvaluations(result.PolicyEvaluations))
+}
+
+// failedResourceEvaluationDiagnostics returns an error listing the violations in state when the evaluation failed
+// and fail_on_violation is set
+func failedResourceEvaluationDiagnostics(d *schema.ResourceData) diag.Diagnostics {
+	if !d.Get("fail_on_violation").(bool) || d.Get("pass").(bool) {
+		return nil
+	}
+
+	var failures []string
+	for _, p := range d.Get("policy_evaluations").([]interface{}) {
+		policyEvaluation := p.(map[string]interface{})
+		if policyEvaluation["pass"].(bool) {
+			continue
+		}
+
+		for _, v := range policyEvaluation["violations"].([]interface{}) {
+			violation := v.(map[string]interface{})
+			if violation["pass"].(bool) {
+				continue
+			}
+
+			failures = append(failures, fmt.Sprintf("  - %s: %s: %s", policyEvaluation["policy_version_id"], violation["name"], violation["message"]))
+		}
+	}
+
+	return diag.Diagnostics{
+		{
+			Severity: diag.Error,
+			Summary:  "Resource failed policy evaluation",
+			Detail: fmt.Sprintf(
+				"%s failed evaluation %s against policy group %s:\n%s",
+				d.Get("resource_uri"), d.Id(), d.Get("policy_group"), strings.Join(failures, "\n"),
+			),
+		},
+	}
+}
+
+func resourceEvaluationPassSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "Whether the resource passed every policy in the policy group",
+		Type:        schema.TypeBool,
+		Computed:    true,
+	}
+}
+
+func policyEvaluationsSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "The result of each policy in the policy group",
+		Type:        schema.TypeList,
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"id": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"policy_version_id": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"pass": {
+					Type:     schema.TypeBool,
+					Computed: true,
+				},
+				"violations": {
+					Type:     schema.TypeList,
+					Computed: true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"id": {
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+							"name": {
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+							"description": {
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+							"message": {
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+							"link": {
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+							"pass": {
+								Type:     schema.TypeBool,
+								Computed: true,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func flattenPolicyEvaluations(policyEvaluations []*v1alpha1.PolicyEvaluation) []interface{} {
+	var flattened []interface{}
+	for _, policyEvaluation := range policyEvaluations {
+		var violations []interface{}
+		for _, violation := range policyEvaluation.Violations {
+			violations = append(violations, map[string]interface{}{
+				"id":          violation.Id,
+				"name":        violation.Name,
+				"description": violation.Description,
+				"message":     violation.Message,
+				"link":        violation.Link,
+				"pass":        violation.Pass,
+			})
+		}
+
+		flattened = append(flattened, map[string]interface{}{
+			"id":                policyEvaluation.Id,
+			"policy_version_id": policyEvaluation.PolicyVersionId,
+			"pass":              policyEvaluation.Pass,
+			"violations":        violations,
+		})
+	}
+
+	return flattened
+}
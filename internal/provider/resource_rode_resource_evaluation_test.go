@@ -0,0 +1,203 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/rode/rode/proto/v1alpha1"
+	"github.com/rode/rode/proto/v1alpha1fakes"
+)
+
+func TestAccResourceEvaluation_basic(t *testing.T) {
+	resourceName := "rode_resource_evaluation.test"
+	name := fmt.Sprintf("tf-acc-%s", strings.ToLower(fake.LetterN(10)))
+	resourceUri := testAccResourceUri()
+	baseConfig := testAccResourceEvaluationBaseConfig(name, minimalPolicy)
+	config := baseConfig + testAccResourceEvaluationConfig(resourceUri)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProvidersFactory,
+		Steps: []resource.TestStep{
+			{
+				Config: baseConfig,
+				Check:  testAccCreateOccurrence("rode_collector.test", resourceUri),
+			},
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttr(resourceName, "pass", "true"),
+					resource.TestCheckResourceAttr(resourceName, "source_name", defaultEvaluationSourceName),
+					resource.TestCheckResourceAttr(resourceName, "policy_evaluations.#", "1"),
+					resource.TestCheckResourceAttrPair(resourceName, "policy_evaluations.0.policy_version_id", "rode_policy.test", "policy_version_id"),
+					resource.TestCheckResourceAttr(resourceName, "policy_evaluations.0.violations.0.id", "valid"),
+					resource.TestCheckResourceAttr(resourceName, "policy_evaluations.0.violations.0.pass", "true"),
+					testAccPolicyAssignedOutOfBand("rode_policy.other", "rode_policy_group.test"),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "policy_version_ids.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "policy_evaluations.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceResourceEvaluation_assignmentsChanged(t *testing.T) {
+	ctx := context.Background()
+	rode := &v1alpha1fakes.FakeRodeClient{}
+	meta := &rodeClient{RodeClient: rode}
+	evaluation := resourceResourceEvaluation()
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"resource_uri": "https://harbor.liatr.io/rode/app@sha256:abc",
+		"policy_group": "group",
+	})
+
+	d := schema.TestResourceDataRaw(t, evaluation.Schema, config.Raw)
+	d.SetId("evaluation-1")
+	if err := setResourceEvaluationResult(d, testResourceEvaluationResult("evaluation-1", "policy-a.1")); err != nil {
+		t.Fatal(err)
+	}
+	state := d.State()
+
+	rode.ListPolicyAssignmentsReturns(testPolicyAssignmentsResponse("policy-a.1", "policy-b.1"), nil)
+	diff, err := evaluation.SimpleDiff(ctx, state, config, meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff == nil || diff.RequiresNew() || !diff.Attributes["policy_version_ids.#"].NewComputed {
+		t.Fatalf("expected an in-place update with new policy versions, got %v", diff)
+	}
+
+	// the assignments change again between plan and apply
+	rode.ListPolicyAssignmentsReturns(testPolicyAssignmentsResponse("policy-a.1", "policy-c.1"), nil)
+	rode.EvaluateResourceReturns(testResourceEvaluationResult("evaluation-2", "policy-a.1", "policy-c.1"), nil)
+	newState, diags := evaluation.Apply(ctx, state, diff, meta)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if rode.EvaluateResourceCallCount() != 1 {
+		t.Errorf("expected the resource to be re-evaluated once, got %d evaluations", rode.EvaluateResourceCallCount())
+	}
+
+	if newState.ID != "evaluation-2" {
+		t.Errorf("expected the new evaluation to be stored, got %s", newState.ID)
+	}
+
+	if newState.Attributes["policy_version_ids.#"] != "2" || newState.Attributes["policy_evaluations.1.policy_version_id"] != "policy-c.1" {
+		t.Errorf("expected the new evaluation's policy versions, got %v", newState.Attributes)
+	}
+
+	diff, err = evaluation.SimpleDiff(ctx, newState, config, meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff != nil && !diff.Empty() {
+		t.Errorf("expected an empty plan after the evaluation, got %v", diff.Attributes)
+	}
+}
+
+func testPolicyAssignmentsResponse(policyVersionIds ...string) *v1alpha1.ListPolicyAssignmentsResponse {
+	response := &v1alpha1.ListPolicyAssignmentsResponse{}
+	for _, policyVersionId := range policyVersionIds {
+		response.PolicyAssignments = append(response.PolicyAssignments, &v1alpha1.PolicyAssignment{
+			PolicyVersionId: policyVersionId,
+			PolicyGroup:     "group",
+		})
+	}
+
+	return response
+}
+
+func testResourceEvaluationResult(id string, policyVersionIds ...string) *v1alpha1.ResourceEvaluationResult {
+	result := &v1alpha1.ResourceEvaluationResult{
+		ResourceEvaluation: &v1alpha1.ResourceEvaluation{
+			Id:   id,
+			Pass: true,
+		},
+	}
+	for _, policyVersionId := range policyVersionIds {
+		result.PolicyEvaluations = append(result.PolicyEvaluations, &v1alpha1.PolicyEvaluation{
+			Id:              fmt.Sprintf("%s-%s", id, policyVersionId),
+			PolicyVersionId: policyVersionId,
+			Pass:            true,
+		})
+	}
+
+	return result
+}
+
+func testAccResourceEvaluationBaseConfig(name, regoContent string) string {
+	return fmt.Sprintf(`
+resource "rode_collector" "test" {
+	collector_id = "%[1]s"
+
+	note {
+		kind = "DISCOVERY"
+	}
+}
+
+resource "rode_policy_group" "test" {
+	name = "%[1]s"
+}
+
+resource "rode_policy" "test" {
+	name         = "%[1]s"
+	rego_content = <<EOF
+%[2]s
+EOF
+}
+
+resource "rode_policy" "other" {
+	name         = "%[1]s-other"
+	rego_content = <<EOF
+%[2]s
+EOF
+}
+
+resource "rode_policy_assignment" "test" {
+	policy_group      = rode_policy_group.test.name
+	policy_version_id = rode_policy.test.policy_version_id
+}
+`, name, regoContent)
+}
+
+func testAccResourceEvaluationConfig(resourceUri string) string {
+	return fmt.Sprintf(`
+resource "rode_resource_evaluation" "test" {
+	resource_uri = "%s"
+	policy_group = rode_policy_group.test.name
+
+	depends_on = [rode_policy_assignment.test]
+}
+`, resourceUri)
+}
@@ -21,10 +21,32 @@ import (
 	"time"
 
 	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// listPageSize is the page size used when a data source reads every page of a list RPC
+const listPageSize = 100
+
+// defaultResourceTimeout bounds each create, read, update, and delete unless a resource's timeouts block overrides it
+const defaultResourceTimeout = 5 * time.Minute
+
+func defaultResourceTimeouts() *schema.ResourceTimeout {
+	return &schema.ResourceTimeout{
+		Create: schema.DefaultTimeout(defaultResourceTimeout),
+		Read:   schema.DefaultTimeout(defaultResourceTimeout),
+		Update: schema.DefaultTimeout(defaultResourceTimeout),
+		Delete: schema.DefaultTimeout(defaultResourceTimeout),
+	}
+}
+
+// formatProtoTimestamp formats the timestamp as RFC 3339, or returns an empty string if it isn't set
 func formatProtoTimestamp(timestamp *timestamppb.Timestamp) string {
+	if timestamp == nil {
+		return ""
+	}
+
 	return timestamp.AsTime().Format(time.RFC3339Nano)
 }
 
@@ -55,3 +77,34 @@ func parsePolicyVersionId(policyVersionId string) (id *policyVersionIdComponents
 		version,
 	}, nil
 }
+
+// combineFilters joins the non-empty CEL expressions so that all of them must match
+func combineFilters(filters ...string) string {
+	var expressions []string
+	for _, filter := range filters {
+		if filter != "" {
+			expressions = append(expressions, fmt.Sprintf("(%s)", filter))
+		}
+	}
+
+	return strings.Join(expressions, " && ")
+}
+
+// dataSourceId derives a stable id for a data source from the arguments used to query Rode
+func dataSourceId(arguments ...string) string {
+	return strconv.Itoa(schema.HashString(strings.Join(arguments, "|")))
+}
+
+// durationValidateDiagFunc checks that a string can be parsed by time.ParseDuration, e.g., "500ms" or "1m30s"
+var durationValidateDiagFunc = validation.ToDiagFunc(func(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+
+	if _, err := time.ParseDuration(v); err != nil {
+		return nil, []error{fmt.Errorf("expected %s to be a duration such as \"30s\": %s", k, err)}
+	}
+
+	return nil, nil
+})